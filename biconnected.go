@@ -0,0 +1,192 @@
+package graph
+
+// BlockCutTree shows how the blocks of an undirected graph, its biconnected
+// components, are joined together at cut vertices. Removing a cut vertex
+// disconnects the blocks on either side of it.
+type BlockCutTree struct {
+	// Tree has a vertex for each block, numbered 1 to len(Blocks) in the order
+	// of Blocks, followed by a vertex for each cut vertex in the order of
+	// CutVertices. Each block is joined to the cut vertices it contains. A graph
+	// with several components gives a forest.
+	Tree *Graph
+	// The edges of each block, as returned by BiconnectedComponents.
+	Blocks [][]Edge
+	// The cut vertices of the original graph, in increasing order.
+	CutVertices []int
+}
+
+// Runs a depth-first traversal of every component of an undirected graph,
+// passing the edges of each block to block as it is completed and each bridge
+// to bridge. Self-loops belong to no block.
+func (g *Graph) biconnected(block func([]Edge), bridge func(Edge)) error {
+	if g.directed {
+		return ErrDirected
+	}
+
+	data := &TraversalData{}
+	data.Init(g)
+
+	// The earliest entered vertex reachable from each vertex's subtree by at
+	// most one back edge.
+	low := make([]int, adjustSize(g.nVertices))
+	// Edges of the blocks still being built.
+	var stack []Edge
+	// Back edges already on the stack, so parallel copies are pushed once.
+	backEdges := make(map[Edge]bool)
+
+	pve := func(v int, data *TraversalData) Control {
+		low[v] = v
+		return CONTINUE
+	}
+
+	// Once v's subtree is done, its tree edge from the parent closes a block if
+	// nothing in the subtree reaches above the parent. If nothing reaches the
+	// parent either, the edge is a bridge.
+	pvl := func(v int, data *TraversalData) Control {
+		p := data.parent[v]
+		if p == 0 {
+			return CONTINUE
+		}
+		if data.entryTime[low[v]] < data.entryTime[low[p]] {
+			low[p] = low[v]
+		}
+		if data.entryTime[low[v]] < data.entryTime[p] {
+			return CONTINUE
+		}
+
+		i := len(stack) - 1
+		for stack[i] != (Edge{X: p, Y: v}) {
+			i--
+		}
+		block(append([]Edge(nil), stack[i:]...))
+		stack = stack[:i]
+
+		// Traversals never report parallel edges back to the parent, so they are
+		// counted here. A doubled edge is never a bridge.
+		if low[v] == v && g.edgeCount(p, v) == 1 {
+			bridge(Edge{X: p, Y: v})
+		}
+		return CONTINUE
+	}
+
+	// Undirected traversals only report tree edges and back edges to a
+	// non-parent ancestor.
+	pe := func(x int, y int, data *TraversalData) Control {
+		if x == y {
+			return CONTINUE
+		}
+		e := Edge{X: x, Y: y}
+		if data.parent[y] != x {
+			if backEdges[e] {
+				return CONTINUE
+			}
+			backEdges[e] = true
+			if data.entryTime[y] < data.entryTime[low[x]] {
+				low[x] = y
+			}
+		}
+		stack = append(stack, e)
+		return CONTINUE
+	}
+
+	for i := 1; i <= g.nVertices; i++ {
+		if data.discovered[i] == false {
+			g.DepthFirstTraversal(i, pve, pvl, pe, data)
+		}
+	}
+
+	return nil
+}
+
+// Counts the entries for y in x's edge list.
+func (g *Graph) edgeCount(x int, y int) int {
+	count := 0
+	for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+		if edgePointer.y == y {
+			count++
+		}
+	}
+	return count
+}
+
+// Bridges returns every edge of an undirected graph whose removal would
+// disconnect its component, across all components. Each bridge runs from the
+// vertex nearer the traversal root. Weights are not reported. ErrDirected is
+// returned for directed graphs.
+func (g *Graph) Bridges() ([]Edge, error) {
+	var bridges []Edge
+	err := g.biconnected(
+		func([]Edge) {},
+		func(e Edge) { bridges = append(bridges, e) },
+	)
+	if err != nil {
+		return nil, err
+	}
+	return bridges, nil
+}
+
+// BiconnectedComponents returns the edges of each block of an undirected
+// graph, across all components. A block is a maximal subgraph that stays
+// connected after removing any one vertex; every edge belongs to exactly one
+// block, and a bridge is a block by itself. Self-loops are left out, parallel
+// edges appear once, and weights are not reported. ErrDirected is returned for
+// directed graphs.
+func (g *Graph) BiconnectedComponents() ([][]Edge, error) {
+	var blocks [][]Edge
+	err := g.biconnected(
+		func(edges []Edge) { blocks = append(blocks, edges) },
+		func(Edge) {},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// BlockCutTree returns the block-cut tree of an undirected graph. ErrDirected
+// is returned for directed graphs.
+func (g *Graph) BlockCutTree() (*BlockCutTree, error) {
+	blocks, err := g.BiconnectedComponents()
+	if err != nil {
+		return nil, err
+	}
+
+	// The vertices of each block, and how many blocks each vertex is in.
+	members := make([][]int, len(blocks))
+	blockCount := make([]int, adjustSize(g.nVertices))
+	seen := make([]int, adjustSize(g.nVertices))
+	for b, edges := range blocks {
+		for _, e := range edges {
+			for _, v := range []int{e.X, e.Y} {
+				if seen[v] != b+1 {
+					seen[v] = b + 1
+					members[b] = append(members[b], v)
+					blockCount[v]++
+				}
+			}
+		}
+	}
+
+	bct := &BlockCutTree{Tree: &Graph{}, Blocks: blocks}
+	bct.Tree.Init(false, nil)
+	for range blocks {
+		bct.Tree.AddVertex()
+	}
+	// The tree vertex of each cut vertex.
+	node := make([]int, adjustSize(g.nVertices))
+	for v := 1; v <= g.nVertices; v++ {
+		if blockCount[v] > 1 {
+			bct.CutVertices = append(bct.CutVertices, v)
+			node[v] = bct.Tree.AddVertex()
+		}
+	}
+	for b := range blocks {
+		for _, v := range members[b] {
+			if node[v] != 0 {
+				bct.Tree.AddEdge(b+1, node[v])
+			}
+		}
+	}
+
+	return bct, nil
+}
@@ -0,0 +1,188 @@
+package graph
+
+import "testing"
+
+// Two triangles sharing vertex 3, with a tail 5-6 off the second, and a
+// separate component 7-8.
+func bowtieGraph() *Graph {
+	edgeList := []int{
+		1, 2,
+		2, 3,
+		3, 1,
+		3, 4,
+		4, 5,
+		5, 3,
+		5, 6,
+		7, 8,
+	}
+	graph := &Graph{}
+	graph.Init(false, edgeList)
+	return graph
+}
+
+func TestBridges(t *testing.T) {
+	graph := bowtieGraph()
+
+	bridges, err := graph.Bridges()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if len(bridges) != 2 {
+		t.Fatalf("len(bridges) should be 2, got %v", bridges)
+	}
+	found := make(map[Edge]bool)
+	for _, e := range bridges {
+		if e.X > e.Y {
+			e.X, e.Y = e.Y, e.X
+		}
+		found[e] = true
+	}
+	if !found[Edge{X: 5, Y: 6}] || !found[Edge{X: 7, Y: 8}] {
+		t.Errorf("bridges should be 5-6 and 7-8, got %v", bridges)
+	}
+}
+
+func TestBridges_parallelEdge(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		1, 2,
+		2, 3,
+	}
+
+	graph := &Graph{}
+	graph.Init(false, edgeList)
+
+	bridges, err := graph.Bridges()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if len(bridges) != 1 || bridges[0] != (Edge{X: 2, Y: 3}) {
+		t.Errorf("bridges should be [{2 3 0}], got %v", bridges)
+	}
+}
+
+func TestBiconnectedComponents(t *testing.T) {
+	graph := bowtieGraph()
+
+	blocks, err := graph.BiconnectedComponents()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if len(blocks) != 4 {
+		t.Fatalf("len(blocks) should be 4, got %v", blocks)
+	}
+	sizes := make(map[int]int)
+	total := 0
+	for _, block := range blocks {
+		sizes[len(block)]++
+		total += len(block)
+	}
+	if sizes[3] != 2 || sizes[1] != 2 {
+		t.Errorf("blocks should be two triangles and two bridges, got %v", blocks)
+	}
+	if total != 8 {
+		t.Errorf("every edge should be in one block, got %v", blocks)
+	}
+}
+
+func TestBiconnectedComponents_parallelBackEdge(t *testing.T) {
+	// 1-3 is doubled, and closes the triangle as a back edge.
+	graph := &Graph{}
+	graph.Init(false, []int{1, 3, 1, 3, 1, 2, 2, 3})
+
+	blocks, err := graph.BiconnectedComponents()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if len(blocks) != 1 || len(blocks[0]) != 3 {
+		t.Errorf("blocks should be one triangle, got %v", blocks)
+	}
+}
+
+func TestBlockCutTree(t *testing.T) {
+	graph := bowtieGraph()
+
+	bct, err := graph.BlockCutTree()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if len(bct.CutVertices) != 2 || bct.CutVertices[0] != 3 || bct.CutVertices[1] != 5 {
+		t.Errorf("bct.CutVertices should be [3 5], got %v", bct.CutVertices)
+	}
+	if bct.Tree.nVertices != 6 {
+		t.Errorf("bct.Tree.nVertices should be 6, got %v", bct.Tree.nVertices)
+	}
+	// Cut vertex 3 joins both triangles, and 5 joins a triangle and a bridge.
+	if bct.Tree.nEdges != 4 {
+		t.Errorf("bct.Tree.nEdges should be 4, got %v", bct.Tree.nEdges)
+	}
+	node3 := len(bct.Blocks) + 1
+	if bct.Tree.Degree(node3) != 2 {
+		t.Errorf("bct.Tree.Degree(%v) should be 2, got %v", node3, bct.Tree.Degree(node3))
+	}
+}
+
+func TestBridges_directed(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(true, []int{1, 2})
+
+	if _, err := graph.Bridges(); err != ErrDirected {
+		t.Errorf("err should be ErrDirected, got %v", err)
+	}
+}
+
+// CutNodes and BlockCutTree find cut vertices in different ways, so they are
+// checked against each other.
+func TestBlockCutTree_matchesCutNodes(t *testing.T) {
+	// Self-loops, a doubled edge, a star and a cycle with a pendant path.
+	edgeList := []int{
+		1, 1,
+		1, 2,
+		2, 3,
+		2, 3,
+		3, 4,
+		4, 4,
+		5, 6,
+		5, 7,
+		5, 8,
+		9, 10,
+		10, 11,
+		11, 9,
+		11, 12,
+		12, 13,
+	}
+	graphs := []*Graph{bowtieGraph(), &Graph{}}
+	graphs[1].Init(false, edgeList)
+
+	for _, graph := range graphs {
+		cutNodes, err := graph.CutNodes()
+		if err != nil {
+			t.Fatalf("err should be nil, got %v", err)
+		}
+		bct, err := graph.BlockCutTree()
+		if err != nil {
+			t.Fatalf("err should be nil, got %v", err)
+		}
+
+		if len(cutNodes) != len(bct.CutVertices) {
+			t.Errorf("cutNodes should match bct.CutVertices %v, got %v", bct.CutVertices, cutNodes)
+			continue
+		}
+		for i, cutNode := range cutNodes {
+			if cutNode.Vertex != bct.CutVertices[i] {
+				t.Errorf("cutNodes should match bct.CutVertices %v, got %v", bct.CutVertices, cutNodes)
+				break
+			}
+			// Removing a cut vertex leaves one piece for each block it is in.
+			node := len(bct.Blocks) + 1 + i
+			if cutNode.Components != bct.Tree.Degree(node) {
+				t.Errorf("cutNodes[%v].Components should be %v, got %v", i, bct.Tree.Degree(node), cutNode.Components)
+			}
+		}
+	}
+}
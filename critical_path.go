@@ -0,0 +1,108 @@
+package graph
+
+import "math"
+
+// Schedule is the result of critical path analysis on a directed acyclic
+// graph, where vertices are tasks and edges are dependencies between them. All
+// slices are indexed by vertex.
+type Schedule struct {
+	// The earliest time each task can start, with tasks that depend on nothing
+	// starting at 0.
+	EarliestStart []float64
+	// The latest time each task can start without delaying the end of the
+	// schedule.
+	LatestStart []float64
+	// How long each task can be delayed without delaying the end of the
+	// schedule. Tasks on the critical path have no slack.
+	Slack []float64
+	// The critical path: a chain of tasks, each depending on the last, that
+	// takes as long as the whole schedule. nil for an empty graph.
+	Path []int
+	// The time at which the last task finishes.
+	Length float64
+}
+
+// CriticalPath schedules the vertices of a directed acyclic graph using edge
+// weights. An edge from x to y with weight w means y can start no sooner than w
+// after x starts. The vertices themselves take no time, so the schedule ends
+// when the last vertex starts.
+//
+// ErrNotDirected is returned for undirected graphs, and a *CyclicSubgraphError
+// for graphs with a cycle.
+func (g *Graph) CriticalPath() (*Schedule, error) {
+	return g.criticalPath(func(v int) float64 { return 0 }, true)
+}
+
+// CriticalPathDurations schedules the vertices of a directed acyclic graph as
+// tasks taking duration(v) each. An edge from x to y means y can start once x
+// finishes. Edge weights are ignored. Errors are the same as CriticalPath.
+func (g *Graph) CriticalPathDurations(duration func(v int) float64) (*Schedule, error) {
+	return g.criticalPath(duration, false)
+}
+
+// Computes a schedule in which an edge from x to y delays the start of y by
+// duration(x), plus the edge weight if useWeights is set.
+func (g *Graph) criticalPath(duration func(v int) float64, useWeights bool) (*Schedule, error) {
+	sorted, err := g.KahnTopologicalSort(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	delay := func(x int, edgePointer *edge) float64 {
+		if useWeights {
+			return duration(x) + edgePointer.weight
+		}
+		return duration(x)
+	}
+
+	schedule := &Schedule{
+		EarliestStart: make([]float64, adjustSize(g.nVertices)),
+		LatestStart:   make([]float64, adjustSize(g.nVertices)),
+		Slack:         make([]float64, adjustSize(g.nVertices)),
+	}
+	earliest := schedule.EarliestStart
+	latest := schedule.LatestStart
+
+	// The predecessor that held back the earliest start of each vertex, or 0 if
+	// nothing did.
+	critical := make([]int, adjustSize(g.nVertices))
+	// The vertex that finishes last.
+	last := 0
+
+	// Forward pass, in topological order.
+	for _, x := range sorted {
+		if last == 0 || earliest[x]+duration(x) > schedule.Length {
+			last = x
+			schedule.Length = earliest[x] + duration(x)
+		}
+		for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+			y := edgePointer.y
+			if start := earliest[x] + delay(x, edgePointer); critical[y] == 0 || start > earliest[y] {
+				earliest[y] = start
+				critical[y] = x
+			}
+		}
+	}
+
+	// Backward pass, in reverse topological order.
+	for i := len(sorted) - 1; i >= 0; i-- {
+		x := sorted[i]
+		latest[x] = schedule.Length - duration(x)
+		for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+			latest[x] = math.Min(latest[x], latest[edgePointer.y]-delay(x, edgePointer))
+		}
+		schedule.Slack[x] = latest[x] - earliest[x]
+	}
+
+	// The critical path is found by following critical predecessors back from
+	// the vertex that finishes last, rather than by looking for zero slack,
+	// which rounding can hide.
+	for v := last; v != 0; v = critical[v] {
+		schedule.Path = append(schedule.Path, v)
+	}
+	for i, j := 0, len(schedule.Path)-1; i < j; i, j = i+1, j-1 {
+		schedule.Path[i], schedule.Path[j] = schedule.Path[j], schedule.Path[i]
+	}
+
+	return schedule, nil
+}
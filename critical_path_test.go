@@ -0,0 +1,98 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+)
+
+// Two routes from 1 to 4, through 2 or through 3.
+func taskGraph(weights []float64) *Graph {
+	edgeList := []int{
+		1, 2,
+		1, 3,
+		2, 4,
+		3, 4,
+	}
+	graph := &Graph{}
+	graph.InitWeighted(true, edgeList, weights)
+	return graph
+}
+
+func TestCriticalPath(t *testing.T) {
+	graph := taskGraph([]float64{3, 3, 2, 4})
+
+	schedule, err := graph.CriticalPath()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if schedule.Length != 7 {
+		t.Errorf("schedule.Length should be 7, got %v", schedule.Length)
+	}
+	expected := []int{1, 3, 4}
+	if len(schedule.Path) != len(expected) {
+		t.Fatalf("schedule.Path should be %v, got %v", expected, schedule.Path)
+	}
+	for i := range expected {
+		if schedule.Path[i] != expected[i] {
+			t.Errorf("schedule.Path should be %v, got %v", expected, schedule.Path)
+			break
+		}
+	}
+	if schedule.EarliestStart[4] != 7 {
+		t.Errorf("schedule.EarliestStart[4] should be 7, got %v", schedule.EarliestStart[4])
+	}
+	if schedule.LatestStart[2] != 5 {
+		t.Errorf("schedule.LatestStart[2] should be 5, got %v", schedule.LatestStart[2])
+	}
+	if schedule.Slack[2] != 2 {
+		t.Errorf("schedule.Slack[2] should be 2, got %v", schedule.Slack[2])
+	}
+	for _, v := range expected {
+		if schedule.Slack[v] != 0 {
+			t.Errorf("schedule.Slack[%v] should be 0, got %v", v, schedule.Slack[v])
+		}
+	}
+}
+
+func TestCriticalPathDurations(t *testing.T) {
+	graph := taskGraph(nil)
+	durations := []float64{0, 3, 2, 4, 1}
+
+	schedule, err := graph.CriticalPathDurations(func(v int) float64 { return durations[v] })
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if schedule.Length != 8 {
+		t.Errorf("schedule.Length should be 8, got %v", schedule.Length)
+	}
+	expected := []int{1, 3, 4}
+	if len(schedule.Path) != len(expected) {
+		t.Fatalf("schedule.Path should be %v, got %v", expected, schedule.Path)
+	}
+	for i := range expected {
+		if schedule.Path[i] != expected[i] {
+			t.Errorf("schedule.Path should be %v, got %v", expected, schedule.Path)
+			break
+		}
+	}
+	if schedule.EarliestStart[2] != 3 {
+		t.Errorf("schedule.EarliestStart[2] should be 3, got %v", schedule.EarliestStart[2])
+	}
+	if schedule.LatestStart[4] != 7 {
+		t.Errorf("schedule.LatestStart[4] should be 7, got %v", schedule.LatestStart[4])
+	}
+	if schedule.Slack[2] != 2 {
+		t.Errorf("schedule.Slack[2] should be 2, got %v", schedule.Slack[2])
+	}
+}
+
+func TestCriticalPath_cycle(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(true, []int{1, 2, 2, 1})
+
+	if _, err := graph.CriticalPath(); !errors.Is(err, ErrCycle) {
+		t.Errorf("err should match ErrCycle, got %v", err)
+	}
+}
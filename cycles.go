@@ -0,0 +1,148 @@
+package graph
+
+// ElementaryCycles calls fn with every elementary cycle of a directed graph,
+// using Johnson's algorithm. A cycle is given as its vertices in edge order,
+// starting from its lowest vertex; the last vertex has an edge back to the
+// first. A self-loop is a cycle of one vertex. The slice is reused between
+// calls, so fn must copy it to keep it. Returning STOP from fn ends the
+// enumeration.
+//
+// A maxLength above 0 limits cycles to that many vertices. Johnson's blocking
+// is not valid under a length limit, so bounded searches fall back to plain
+// backtracking, which is only efficient for short limits.
+//
+// Each search is confined to one strongly connected component, since no cycle
+// leaves its component. ErrNotDirected is returned for undirected graphs.
+func (g *Graph) ElementaryCycles(maxLength int, fn func(cycle []int) Control) error {
+	count, scc, err := g.StronglyConnectedComponents()
+	if err != nil {
+		return err
+	}
+
+	// The vertices of each component in increasing order, and the position of
+	// each vertex within its component.
+	components := make([][]int, adjustSize(count))
+	position := make([]int, adjustSize(g.nVertices))
+	for v := 1; v <= g.nVertices; v++ {
+		position[v] = len(components[scc[v]])
+		components[scc[v]] = append(components[scc[v]], v)
+	}
+	// Scratch space for building subgraphs.
+	index := make([]int, adjustSize(g.nVertices))
+
+	bounded := maxLength > 0
+	var buffer []int
+
+	// Find every cycle whose lowest vertex is s. The search is confined to the
+	// strongly connected component of s among vertices no lower than s.
+	for s := 1; s <= g.nVertices; s++ {
+		members := components[scc[s]][position[s]:]
+		// Only a self-loop can make a cycle on its own.
+		if len(members) == 1 && !g.HasEdge(s, s) {
+			continue
+		}
+		// s becomes vertex 1 of the subgraph.
+		sub, mapping := g.inducedSubgraph(members, index)
+		_, subScc, err := sub.StronglyConnectedComponents()
+		if err != nil {
+			return err
+		}
+
+		// Neighbors within the component of s. Parallel edges are collapsed so
+		// that each cycle is reported once.
+		adjacency := make([][]int, adjustSize(sub.nVertices))
+		seen := make([]int, adjustSize(sub.nVertices))
+		for v := 1; v <= sub.nVertices; v++ {
+			if subScc[v] != subScc[1] {
+				continue
+			}
+			for edgePointer := sub.edges[v]; edgePointer != nil; edgePointer = edgePointer.next {
+				w := edgePointer.y
+				if subScc[w] == subScc[1] && seen[w] != v {
+					seen[w] = v
+					adjacency[v] = append(adjacency[v], w)
+				}
+			}
+		}
+
+		blocked := make([]bool, adjustSize(sub.nVertices))
+		// blockedBy[w] holds the vertices to unblock once w is unblocked.
+		blockedBy := make([]map[int]bool, adjustSize(sub.nVertices))
+		unblock := func(u int) {
+			blocked[u] = false
+			work := []int{u}
+			for len(work) != 0 {
+				x := work[len(work)-1]
+				work = work[:len(work)-1]
+				for w := range blockedBy[x] {
+					delete(blockedBy[x], w)
+					if blocked[w] {
+						blocked[w] = false
+						work = append(work, w)
+					}
+				}
+			}
+		}
+
+		// The explicit stack of Johnson's recursive circuit search. found records
+		// whether a cycle was completed beneath the vertex.
+		type frame struct {
+			v     int
+			next  int // Index into adjacency[v].
+			found bool
+		}
+		var path []int
+		var stack []frame
+		push := func(v int) {
+			path = append(path, v)
+			blocked[v] = true
+			stack = append(stack, frame{v: v})
+		}
+
+		push(1)
+		for len(stack) != 0 {
+			top := &stack[len(stack)-1]
+
+			if top.next < len(adjacency[top.v]) {
+				w := adjacency[top.v][top.next]
+				top.next++
+				if w == 1 {
+					top.found = true
+					buffer = buffer[:0]
+					for _, v := range path {
+						buffer = append(buffer, mapping[v])
+					}
+					if fn(buffer) == STOP {
+						return nil
+					}
+				} else if !blocked[w] && (!bounded || len(path) < maxLength) {
+					push(w)
+				}
+				continue
+			}
+
+			// Every edge of v has been followed.
+			v, found := top.v, top.found
+			if bounded {
+				blocked[v] = false
+			} else if found {
+				unblock(v)
+			} else {
+				// v stays blocked until one of its neighbors is unblocked.
+				for _, w := range adjacency[v] {
+					if blockedBy[w] == nil {
+						blockedBy[w] = make(map[int]bool)
+					}
+					blockedBy[w][v] = true
+				}
+			}
+			path = path[:len(path)-1]
+			stack = stack[:len(stack)-1]
+			if found && len(stack) != 0 {
+				stack[len(stack)-1].found = true
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Every ordered pair of 1, 2 and 3 is an edge.
+func completeDigraph() *Graph {
+	edgeList := []int{
+		1, 2, 2, 1,
+		1, 3, 3, 1,
+		2, 3, 3, 2,
+	}
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+	return graph
+}
+
+func TestElementaryCycles(t *testing.T) {
+	graph := completeDigraph()
+
+	found := make(map[string]bool)
+	err := graph.ElementaryCycles(0, func(cycle []int) Control {
+		found[fmt.Sprint(cycle)] = true
+		return CONTINUE
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	expected := []string{"[1 2]", "[1 3]", "[2 3]", "[1 2 3]", "[1 3 2]"}
+	if len(found) != len(expected) {
+		t.Errorf("found should have %v cycles, got %v", len(expected), found)
+	}
+	for _, cycle := range expected {
+		if !found[cycle] {
+			t.Errorf("cycle %v should be found, got %v", cycle, found)
+		}
+	}
+}
+
+func TestElementaryCycles_maxLength(t *testing.T) {
+	graph := completeDigraph()
+
+	count := 0
+	graph.ElementaryCycles(2, func(cycle []int) Control {
+		count++
+		if len(cycle) > 2 {
+			t.Errorf("cycle %v should have at most 2 vertices", cycle)
+		}
+		return CONTINUE
+	})
+
+	if count != 3 {
+		t.Errorf("count should be 3, got %v", count)
+	}
+}
+
+func TestElementaryCycles_stop(t *testing.T) {
+	graph := completeDigraph()
+
+	count := 0
+	graph.ElementaryCycles(0, func(cycle []int) Control {
+		count++
+		return STOP
+	})
+
+	if count != 1 {
+		t.Errorf("count should be 1, got %v", count)
+	}
+}
+
+func TestElementaryCycles_selfLoopsAndComponents(t *testing.T) {
+	edgeList := []int{
+		1, 1,
+		1, 2,
+		2, 3,
+		2, 3, // Parallel edges do not repeat a cycle.
+		3, 4,
+		4, 2,
+		4, 5,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	var cycles []string
+	graph.ElementaryCycles(0, func(cycle []int) Control {
+		cycles = append(cycles, fmt.Sprint(cycle))
+		return CONTINUE
+	})
+
+	if len(cycles) != 2 || cycles[0] != "[1]" || cycles[1] != "[2 3 4]" {
+		t.Errorf("cycles should be [[1] [2 3 4]], got %v", cycles)
+	}
+}
+
+func TestElementaryCycles_longChain(t *testing.T) {
+	// A long acyclic chain with a single short cycle near each end. Acyclic
+	// vertices should cost next to nothing.
+	n := 100000
+	edgeList := make([]int, 0, 2*n)
+	for v := 1; v < n; v++ {
+		edgeList = append(edgeList, v, v+1)
+	}
+	edgeList = append(edgeList, 3, 2, n, n-1)
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	var cycles []string
+	err := graph.ElementaryCycles(0, func(cycle []int) Control {
+		cycles = append(cycles, fmt.Sprint(cycle))
+		return CONTINUE
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	expected := fmt.Sprint([]int{n - 1, n})
+	if len(cycles) != 2 || cycles[0] != "[2 3]" || cycles[1] != expected {
+		t.Errorf("cycles should be [[2 3] %v], got %v", expected, cycles)
+	}
+}
+
+func TestElementaryCycles_undirected(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(false, []int{1, 2})
+
+	err := graph.ElementaryCycles(0, func(cycle []int) Control { return CONTINUE })
+	if err != ErrNotDirected {
+		t.Errorf("err should be ErrNotDirected, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotDirected is returned by algorithms that require a directed graph.
+	ErrNotDirected = errors.New("graph: operation requires a directed graph")
+	// ErrDirected is returned by algorithms that require an undirected graph.
+	ErrDirected = errors.New("graph: operation requires an undirected graph")
+	// ErrUnknownVertex is returned when a vertex or key is not part of the graph.
+	ErrUnknownVertex = errors.New("graph: unknown vertex")
+	// ErrUnknownEdge is returned when an edge is not part of the graph.
+	ErrUnknownEdge = errors.New("graph: unknown edge")
+	// ErrOddEdgeList is returned when an edge list has an unpaired vertex.
+	ErrOddEdgeList = errors.New("graph: edge list has odd length")
+	// ErrWeightCount is returned when the number of weights does not match the
+	// number of edges.
+	ErrWeightCount = errors.New("graph: weight count does not match edge count")
+	// ErrCycle is returned by algorithms that require an acyclic graph. Errors
+	// carrying the cycle found are of type *CycleError or *CyclicSubgraphError
+	// and match ErrCycle with errors.Is.
+	ErrCycle = errors.New("graph: graph contains a cycle")
+	// ErrNegativeWeight is returned by algorithms that cannot handle negative
+	// edge weights when one is found.
+	ErrNegativeWeight = errors.New("graph: negative edge weight")
+	// ErrInconsistentHeuristic is returned by CheckHeuristic when an A*
+	// heuristic is not consistent.
+	ErrInconsistentHeuristic = errors.New("graph: inconsistent heuristic")
+	// ErrTooManyVertices is returned by algorithms whose cost grows
+	// exponentially with the number of vertices when a graph is over their
+	// limit.
+	ErrTooManyVertices = errors.New("graph: too many vertices")
+)
+
+// CycleError is returned when a cycle prevents an algorithm from completing.
+type CycleError struct {
+	// The back edge that closed the cycle. Its weight is not reported.
+	Edge Edge
+	// The vertices of the cycle in edge order, from Edge.Y to Edge.X.
+	Cycle []int
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("graph: cycle %v closed by back edge %d, %d", e.Cycle, e.Edge.X, e.Edge.Y)
+}
+
+// Is reports whether target is ErrCycle.
+func (e *CycleError) Is(target error) bool {
+	return target == ErrCycle
+}
+
+// CyclicSubgraphError is returned when a topological sort stops short because
+// of a cycle. It holds every vertex left unsorted: those on a cycle and those
+// that can only be reached through one.
+type CyclicSubgraphError struct {
+	// The unsorted vertices in increasing order.
+	Vertices []int
+	// The subgraph induced by Vertices. Vertex i of Subgraph is Vertices[i-1].
+	Subgraph *Graph
+}
+
+func (e *CyclicSubgraphError) Error() string {
+	return fmt.Sprintf("graph: cycle among unsorted vertices %v", e.Vertices)
+}
+
+// Is reports whether target is ErrCycle.
+func (e *CyclicSubgraphError) Is(target error) bool {
+	return target == ErrCycle
+}
+
+// NegativeCycleError is returned when a negative cycle is reachable from the
+// source, making shortest distances undefined.
+type NegativeCycleError struct {
+	// The vertices of the cycle in edge order. The last vertex has an edge back
+	// to the first.
+	Cycle []int
+}
+
+func (e *NegativeCycleError) Error() string {
+	return fmt.Sprintf("graph: negative cycle %v", e.Cycle)
+}
+
+// Returns an error wrapping ErrUnknownVertex if v is not a vertex of the graph.
+func (g *Graph) checkVertex(v int) error {
+	if v < 1 || v > g.nVertices {
+		return fmt.Errorf("%w: %d", ErrUnknownVertex, v)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package graph
+
+import "context"
+
+// ExecuteOptions controls how Execute runs a graph.
+type ExecuteOptions struct {
+	// The most vertices that may run at once. 0 or less means no limit.
+	MaxParallel int
+	// By default the first failure cancels the context passed to running
+	// vertices, and no more vertices are started. With ContinueOnError set,
+	// only the vertices that depend on a failed vertex are skipped.
+	ContinueOnError bool
+}
+
+// ExecuteReport records what happened to each vertex during Execute.
+type ExecuteReport struct {
+	// The vertices that ran without error, in the order they finished.
+	Ran []int
+	// The error returned by each vertex that failed.
+	Failed map[int]error
+	// The vertices that were never started, in increasing order.
+	Skipped []int
+}
+
+// The outcome of running one vertex.
+type executeResult struct {
+	vertex int
+	err    error
+}
+
+// Execute runs fn for every vertex of a directed acyclic graph, each in its own
+// goroutine. An edge from x to y means y depends on x, so y is started only
+// once x has run without error. Vertices are started as soon as they are
+// ready, and fn may be called concurrently. Execute returns once every started
+// vertex has finished.
+//
+// No more vertices are started once ctx is done. fn should return promptly
+// when its context is canceled, since Execute waits for it.
+//
+// The report is returned along with the first error returned by fn, or with
+// ctx's error if ctx ended the run early. ErrNotDirected is returned for
+// undirected graphs and a *CyclicSubgraphError for graphs with a cycle, in
+// which case nothing is run and the report is nil.
+func (g *Graph) Execute(ctx context.Context, fn func(ctx context.Context, v int) error, options ExecuteOptions) (*ExecuteReport, error) {
+	// Kahn's algorithm finds any cycle before anything is run.
+	if _, err := g.KahnTopologicalSort(nil); err != nil {
+		return nil, err
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	report := &ExecuteReport{Failed: make(map[int]error)}
+	// The number of predecessors each vertex is still waiting on.
+	waiting := g.inDegrees()
+	// Whether each vertex has been started.
+	started := make([]bool, adjustSize(g.nVertices))
+
+	var ready []int
+	for v := 1; v <= g.nVertices; v++ {
+		if waiting[v] == 0 {
+			ready = append(ready, v)
+		}
+	}
+
+	results := make(chan executeResult)
+	running := 0
+	stopping := false
+	var firstErr error
+
+	for {
+		for len(ready) != 0 && !stopping && (options.MaxParallel <= 0 || running < options.MaxParallel) {
+			if runCtx.Err() != nil {
+				stopping = true
+				break
+			}
+			v := ready[0]
+			ready = ready[1:]
+			started[v] = true
+			running++
+			go func(v int) {
+				results <- executeResult{vertex: v, err: fn(runCtx, v)}
+			}(v)
+		}
+		if running == 0 {
+			break
+		}
+
+		result := <-results
+		running--
+		if result.err != nil {
+			report.Failed[result.vertex] = result.err
+			if firstErr == nil {
+				firstErr = result.err
+			}
+			if !options.ContinueOnError {
+				stopping = true
+				cancel()
+			}
+			// Dependents of a failed vertex are never released.
+			continue
+		}
+
+		report.Ran = append(report.Ran, result.vertex)
+		for edgePointer := g.edges[result.vertex]; edgePointer != nil; edgePointer = edgePointer.next {
+			waiting[edgePointer.y]--
+			if waiting[edgePointer.y] == 0 {
+				ready = append(ready, edgePointer.y)
+			}
+		}
+	}
+
+	for v := 1; v <= g.nVertices; v++ {
+		if !started[v] {
+			report.Skipped = append(report.Skipped, v)
+		}
+	}
+
+	if firstErr != nil {
+		return report, firstErr
+	}
+	if len(report.Skipped) != 0 {
+		return report, ctx.Err()
+	}
+	return report, nil
+}
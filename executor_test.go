@@ -0,0 +1,190 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// 1 and 2 both feed 3, which feeds 4. 5 stands alone.
+func buildGraph() *Graph {
+	edgeList := []int{
+		1, 3,
+		2, 3,
+		3, 4,
+	}
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+	graph.AddVertex()
+	return graph
+}
+
+func TestExecute(t *testing.T) {
+	graph := buildGraph()
+
+	var mu sync.Mutex
+	finished := make(map[int]bool)
+	fn := func(ctx context.Context, v int) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, u := range []int{1, 2, 3, 4, 5} {
+			if graph.HasEdge(u, v) && !finished[u] {
+				t.Errorf("%v should finish before %v starts", u, v)
+			}
+		}
+		finished[v] = true
+		return nil
+	}
+
+	report, err := graph.Execute(context.Background(), fn, ExecuteOptions{})
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+	if len(report.Ran) != 5 {
+		t.Errorf("report.Ran should hold every vertex, got %v", report.Ran)
+	}
+	if len(report.Failed) != 0 || len(report.Skipped) != 0 {
+		t.Errorf("nothing should fail or be skipped, got %v and %v", report.Failed, report.Skipped)
+	}
+}
+
+func TestExecute_maxParallel(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(true, nil)
+	for i := 0; i < 8; i++ {
+		graph.AddVertex()
+	}
+
+	var mu sync.Mutex
+	running, most := 0, 0
+	// Closed once two vertices are running together.
+	paired := make(chan struct{})
+	var pairOnce sync.Once
+	fn := func(ctx context.Context, v int) error {
+		mu.Lock()
+		running++
+		if running > most {
+			most = running
+		}
+		if running == 2 {
+			pairOnce.Do(func() { close(paired) })
+		}
+		mu.Unlock()
+
+		// Hold the first vertex until a second one starts. The timeout only
+		// matters if the limit stops that from happening.
+		select {
+		case <-paired:
+		case <-time.After(time.Second):
+		}
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil
+	}
+
+	if _, err := graph.Execute(context.Background(), fn, ExecuteOptions{MaxParallel: 2}); err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+	if most != 2 {
+		t.Errorf("2 vertices should run at once, got %v", most)
+	}
+}
+
+func TestExecute_failFast(t *testing.T) {
+	graph := buildGraph()
+	failure := errors.New("failed")
+
+	// Only one vertex runs at a time, so 5 has not started when 1 fails.
+	fn := func(ctx context.Context, v int) error {
+		if v == 1 {
+			return failure
+		}
+		return nil
+	}
+
+	report, err := graph.Execute(context.Background(), fn, ExecuteOptions{MaxParallel: 1})
+	if err != failure {
+		t.Errorf("err should be failure, got %v", err)
+	}
+	if report.Failed[1] != failure {
+		t.Errorf("report.Failed[1] should be failure, got %v", report.Failed[1])
+	}
+	expected := []int{2, 3, 4, 5}
+	if len(report.Ran) != 0 || len(report.Skipped) != len(expected) {
+		t.Fatalf("report.Skipped should be %v, got %v, ran %v", expected, report.Skipped, report.Ran)
+	}
+	for i := range expected {
+		if report.Skipped[i] != expected[i] {
+			t.Errorf("report.Skipped should be %v, got %v", expected, report.Skipped)
+			break
+		}
+	}
+}
+
+func TestExecute_continueOnError(t *testing.T) {
+	graph := buildGraph()
+	failure := errors.New("failed")
+
+	fn := func(ctx context.Context, v int) error {
+		if v == 2 {
+			return failure
+		}
+		return nil
+	}
+
+	report, err := graph.Execute(context.Background(), fn, ExecuteOptions{ContinueOnError: true})
+	if err != failure {
+		t.Errorf("err should be failure, got %v", err)
+	}
+	if len(report.Ran) != 2 {
+		t.Errorf("report.Ran should hold 1 and 5, got %v", report.Ran)
+	}
+	expected := []int{3, 4}
+	if len(report.Skipped) != len(expected) {
+		t.Fatalf("report.Skipped should be %v, got %v", expected, report.Skipped)
+	}
+	for i := range expected {
+		if report.Skipped[i] != expected[i] {
+			t.Errorf("report.Skipped should be %v, got %v", expected, report.Skipped)
+			break
+		}
+	}
+}
+
+func TestExecute_canceled(t *testing.T) {
+	graph := buildGraph()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := graph.Execute(ctx, func(ctx context.Context, v int) error {
+		t.Errorf("%v should not run", v)
+		return nil
+	}, ExecuteOptions{})
+	if err != context.Canceled {
+		t.Errorf("err should be context.Canceled, got %v", err)
+	}
+	if len(report.Skipped) != 5 {
+		t.Errorf("report.Skipped should hold every vertex, got %v", report.Skipped)
+	}
+}
+
+func TestExecute_cycle(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(true, []int{1, 2, 2, 1})
+
+	report, err := graph.Execute(context.Background(), func(ctx context.Context, v int) error {
+		t.Errorf("%v should not run", v)
+		return nil
+	}, ExecuteOptions{})
+	if !errors.Is(err, ErrCycle) {
+		t.Errorf("err should match ErrCycle, got %v", err)
+	}
+	if report != nil {
+		t.Errorf("report should be nil, got %v", report)
+	}
+}
@@ -2,12 +2,20 @@ package graph
 
 import (
 	"container/list"
-	"log"
+	"fmt"
 )
 
 type edge struct {
-	y    int // The connected vertex.
-	next *edge
+	y      int     // The connected vertex.
+	weight float64 // The cost of traversing the edge. 1 for unweighted graphs.
+	next   *edge
+}
+
+// Edge is an edge from X to Y, as returned by algorithms that produce edges.
+type Edge struct {
+	X      int
+	Y      int
+	Weight float64
 }
 
 // Graph is a graph data structure.
@@ -31,8 +39,21 @@ type TraversalData struct {
 }
 
 // Used to provide arbitrary processing behavior to traversal methods.
-type processVertex func(int, *TraversalData)
-type processEdge func(int, int, *TraversalData)
+type processVertex func(int, *TraversalData) Control
+type processEdge func(int, int, *TraversalData) Control
+
+// Control is returned by traversal callbacks to direct the rest of the
+// traversal.
+type Control int
+
+const (
+	// CONTINUE carries on with the traversal.
+	CONTINUE Control = iota
+	// SKIP prunes the traversal below the current vertex or edge.
+	SKIP
+	// STOP ends the traversal.
+	STOP
+)
 
 // EdgeClass is used to classify an edge.
 type EdgeClass int
@@ -48,37 +69,209 @@ const (
 	CROSS
 )
 
+// CutNodeClass is used to classify a cut-node, also known as an articulation
+// vertex.
+type CutNodeClass int
+
+const (
+	// ROOT indicates the root of a traversal tree with more than one child.
+	ROOT CutNodeClass = iota
+	// PARENT indicates a vertex that is the earliest reachable ancestor of one
+	// of its children, cutting that child's subtree off.
+	PARENT
+	// BRIDGE indicates an endpoint of a bridge edge, other than a leaf.
+	BRIDGE
+)
+
+func (c CutNodeClass) String() string {
+	switch c {
+	case ROOT:
+		return "root"
+	case PARENT:
+		return "parent"
+	case BRIDGE:
+		return "bridge"
+	}
+	return fmt.Sprintf("CutNodeClass(%d)", int(c))
+}
+
+// CutNode is a cut-node found by CutNodes.
+type CutNode struct {
+	Vertex int
+	Class  CutNodeClass
+	// The number of pieces the vertex's connected component splits into when
+	// the vertex is removed. Always at least 2.
+	Components int
+}
+
 // Adjust array size for ignored index 0 where necessary.
 func adjustSize(size int) int {
 	return size + 1
 }
 
-// Insert an edge into the graph. Part of initialization.
-func (g *Graph) insertEdge(directed bool, x int, y int) {
-	g.edges[x] = &edge{y: y, next: g.edges[x]} // Place node at the head.
+// Insert an edge into the graph. Used by initialization and AddEdge.
+func (g *Graph) insertEdge(directed bool, x int, y int, weight float64) {
+	g.edges[x] = &edge{y: y, weight: weight, next: g.edges[x]} // Place node at the head.
 	if directed {
 		g.nEdges++
 	} else {
-		g.insertEdge(true, y, x)
+		g.insertEdge(true, y, x, weight)
 	}
 }
 
-// Init initializes the graph.
-func (g *Graph) Init(directed bool, edgeList []int) {
-	g.directed = directed
+// Returns a deep copy of the graph. Adjacency lists keep their order.
+func (g *Graph) clone() *Graph {
+	c := &Graph{
+		directed:  g.directed,
+		edges:     make([]*edge, len(g.edges)),
+		nEdges:    g.nEdges,
+		nVertices: g.nVertices,
+	}
+	for x := range g.edges {
+		tail := &c.edges[x]
+		for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+			*tail = &edge{y: edgePointer.y, weight: edgePointer.weight}
+			tail = &(*tail).next
+		}
+	}
+	return c
+}
+
+// Transpose returns a copy of the graph with every edge reversed. An
+// undirected graph is its own transpose, so a plain copy is returned.
+func (g *Graph) Transpose() *Graph {
+	if !g.directed {
+		return g.clone()
+	}
+	t := &Graph{
+		directed:  true,
+		edges:     make([]*edge, len(g.edges)),
+		nVertices: g.nVertices,
+	}
+	for x := 1; x <= g.nVertices; x++ {
+		for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+			t.insertEdge(true, edgePointer.y, x, edgePointer.weight)
+		}
+	}
+	return t
+}
+
+// Returns an undirected graph with an edge wherever g has an edge in either
+// direction, for algorithms that ignore direction. Undirected graphs are
+// returned as they are.
+func (g *Graph) undirected() *Graph {
+	if !g.directed {
+		return g
+	}
+	u := &Graph{
+		directed:  false,
+		edges:     make([]*edge, adjustSize(g.nVertices)),
+		nVertices: g.nVertices,
+	}
+	for x := 1; x <= g.nVertices; x++ {
+		for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+			u.insertEdge(false, x, edgePointer.y, edgePointer.weight)
+		}
+	}
+	return u
+}
+
+// InducedSubgraph returns the subgraph made up of the given vertices and every
+// edge between them. The vertices are renumbered from 1 in the order given, and
+// the returned mapping gives the original vertex for each new one.
+func (g *Graph) InducedSubgraph(vertices []int) (*Graph, []int) {
+	return g.inducedSubgraph(vertices, make([]int, adjustSize(g.nVertices)))
+}
+
+// Builds the subgraph for InducedSubgraph. index is scratch space for the new
+// number of each original vertex, or 0 if excluded. It must be all zeros, and
+// is left that way, so callers building many small subgraphs can reuse it.
+func (g *Graph) inducedSubgraph(vertices []int, index []int) (*Graph, []int) {
+	mapping := []int{0}
+	for _, v := range vertices {
+		if index[v] == 0 {
+			mapping = append(mapping, v)
+			index[v] = len(mapping) - 1
+		}
+	}
+
+	sub := &Graph{
+		directed:  g.directed,
+		edges:     make([]*edge, len(mapping)),
+		nVertices: len(mapping) - 1,
+	}
+	entries := 0
+	for x := 1; x <= sub.nVertices; x++ {
+		tail := &sub.edges[x]
+		for edgePointer := g.edges[mapping[x]]; edgePointer != nil; edgePointer = edgePointer.next {
+			if y := index[edgePointer.y]; y != 0 {
+				*tail = &edge{y: y, weight: edgePointer.weight}
+				tail = &(*tail).next
+				entries++
+			}
+		}
+	}
+	// Undirected edges are stored once in each direction.
+	sub.nEdges = entries
+	if !g.directed {
+		sub.nEdges = entries / 2
+	}
+
+	for _, v := range mapping[1:] {
+		index[v] = 0
+	}
+	return sub, mapping
+}
+
+// Init initializes the graph. Every edge is given a weight of 1.
+//
+// Vertices must be numbered 1 to n, where n is the number of distinct vertices
+// in edgeList. An error wrapping ErrUnknownVertex is returned otherwise, and
+// ErrOddEdgeList is returned if the last edge is missing a vertex. The graph is
+// left unchanged on error.
+func (g *Graph) Init(directed bool, edgeList []int) error {
+	return g.InitWeighted(directed, edgeList, nil)
+}
+
+// InitWeighted initializes the graph with a weight for each edge. weights[i]
+// is the weight of the edge formed by edgeList[2*i] and edgeList[2*i+1]. A nil
+// weights slice gives every edge a weight of 1. Validation is the same as Init,
+// and ErrWeightCount is returned if there is not one weight per edge.
+func (g *Graph) InitWeighted(directed bool, edgeList []int, weights []float64) error {
 	length := len(edgeList)
+	if length%2 != 0 {
+		return ErrOddEdgeList
+	}
+	if weights != nil && len(weights) != length/2 {
+		return ErrWeightCount
+	}
 	vertexMap := make(map[int]int)
 	for i := 0; i < length; i++ {
 		vertexMap[edgeList[i]] = 1
 	}
-	g.nVertices = len(vertexMap)
+	nVertices := len(vertexMap)
+	for i := 0; i < length; i++ {
+		if v := edgeList[i]; v < 1 || v > nVertices {
+			return fmt.Errorf("%w: %d outside 1 to %d", ErrUnknownVertex, v, nVertices)
+		}
+	}
+
+	g.directed = directed
+	g.nEdges = 0
+	g.nVertices = nVertices
 	g.edges = make([]*edge, adjustSize(g.nVertices))
 	for xIndex := 0; xIndex < length-1; xIndex += 2 {
 		yIndex := xIndex + 1
 		x := edgeList[xIndex]
 		y := edgeList[yIndex]
-		g.insertEdge(directed, x, y)
+		weight := 1.0
+		if weights != nil {
+			weight = weights[xIndex/2]
+		}
+		g.insertEdge(directed, x, y, weight)
 	}
+
+	return nil
 }
 
 // Init initializes TraversalData for traversal.
@@ -91,7 +284,95 @@ func (data *TraversalData) Init(g *Graph) {
 	data.exitTime = make([]int, size)
 }
 
+// Reset clears the data for another traversal of the same graph, reusing its
+// allocations.
+func (data *TraversalData) Reset() {
+	for i := range data.discovered {
+		data.discovered[i] = false
+		data.processed[i] = false
+		data.parent[i] = 0
+		data.entryTime[i] = 0
+		data.exitTime[i] = 0
+	}
+	data.time = 0
+}
+
+// Discovered checks if v has been discovered.
+func (data *TraversalData) Discovered(v int) bool {
+	return data.discovered[v]
+}
+
+// Processed checks if v has been processed.
+func (data *TraversalData) Processed(v int) bool {
+	return data.processed[v]
+}
+
+// Parent returns the vertex v was discovered from, or 0 for a start vertex or
+// an undiscovered vertex.
+func (data *TraversalData) Parent(v int) int {
+	return data.parent[v]
+}
+
+// EntryTime returns when v was entered during a depth-first traversal.
+func (data *TraversalData) EntryTime(v int) int {
+	return data.entryTime[v]
+}
+
+// ExitTime returns when v was exited during a depth-first traversal.
+func (data *TraversalData) ExitTime(v int) int {
+	return data.exitTime[v]
+}
+
+// PathTo returns the tree path from the start vertex to v, or nil if v has not
+// been discovered.
+func (data *TraversalData) PathTo(v int) []int {
+	if data.discovered[v] == false {
+		return nil
+	}
+	path := []int{v}
+	for v = data.parent[v]; v != 0; v = data.parent[v] {
+		path = append(path, v)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// Depth returns the number of tree edges between the start vertex and v, or -1
+// if v has not been discovered.
+func (data *TraversalData) Depth(v int) int {
+	if data.discovered[v] == false {
+		return -1
+	}
+	depth := 0
+	for v = data.parent[v]; v != 0; v = data.parent[v] {
+		depth++
+	}
+	return depth
+}
+
+// IsAncestor checks if u is an ancestor of v, or v itself, in the depth-first
+// tree. Entry and exit times make this constant time.
+func (data *TraversalData) IsAncestor(u int, v int) bool {
+	if data.discovered[u] == false || data.discovered[v] == false {
+		return false
+	}
+	if data.entryTime[v] < data.entryTime[u] {
+		return false
+	}
+	// Everything entered while u is on the path is its descendant.
+	if data.processed[u] == false {
+		return true
+	}
+	return data.entryTime[v] <= data.exitTime[u]
+}
+
 // BreadthFirstTraversal processes vertices in breadth-first order.
+//
+// Returning SKIP from pve leaves the vertex's edges unexplored, and returning it
+// from pe for an undiscovered vertex leaves that vertex undiscovered through
+// the edge. Returning STOP from any callback ends the traversal.
 func (g *Graph) BreadthFirstTraversal(
 	start int,
 	pve processVertex, // Process vertex early.
@@ -99,99 +380,228 @@ func (g *Graph) BreadthFirstTraversal(
 	pe processEdge,
 	data *TraversalData,
 ) *TraversalData {
-	data.discovered[start] = true
+	return g.MultiSourceBreadthFirstTraversal([]int{start}, pve, pvl, pe, data)
+}
+
+// MultiSourceBreadthFirstTraversal is BreadthFirstTraversal seeded with every
+// vertex in starts at once, as if from a virtual vertex joined to each of them.
+// Each vertex is reached from whichever start is nearest, and starts have a
+// parent of 0.
+func (g *Graph) MultiSourceBreadthFirstTraversal(
+	starts []int,
+	pve processVertex,
+	pvl processVertex,
+	pe processEdge,
+	data *TraversalData,
+) *TraversalData {
 	queue := list.New()
-	queue.PushBack(start)
+	for _, start := range starts {
+		if data.discovered[start] == false {
+			data.discovered[start] = true
+			queue.PushBack(start)
+		}
+	}
 
 	for queue.Len() != 0 {
 		x := queue.Remove(queue.Front()).(int)
-		pve(x, data)
+		control := pve(x, data)
+		if control == STOP {
+			return data
+		}
 		data.processed[x] = true
 
 		edgePointer := g.edges[x]
+		if control == SKIP {
+			edgePointer = nil
+		}
 
 		for edgePointer != nil {
 			y := edgePointer.y
+			edgePointer = edgePointer.next
 			if g.directed == true || data.processed[y] == false {
-				pe(x, y, data)
+				control := pe(x, y, data)
+				if control == STOP {
+					return data
+				}
+				if control == SKIP {
+					continue
+				}
 			}
 			if data.discovered[y] == false {
 				queue.PushBack(y)
 				data.discovered[y] = true
 				data.parent[y] = x
 			}
-			edgePointer = edgePointer.next
 		}
 
-		pvl(x, data)
+		if pvl(x, data) == STOP {
+			return data
+		}
 	}
 
 	return data
 }
 
-// DepthFirstTraversal processes vertices in depth-first order.
+// DepthFirstTraversal processes vertices in depth-first order. An explicit
+// stack is used instead of recursion, so arbitrarily deep graphs are safe.
+//
+// Returning SKIP from pve leaves the vertex's edges unexplored, though pvl is
+// still called for it. Returning SKIP from pe for a tree edge leaves the
+// undiscovered vertex unexplored through that edge. Returning STOP from any
+// callback ends the traversal.
 func (g *Graph) DepthFirstTraversal(
-	x int,
+	start int,
 	pve processVertex,
 	pvl processVertex,
 	pe processEdge,
 	data *TraversalData,
 ) *TraversalData {
-	data.discovered[x] = true
-	pve(x, data)
+	// A vertex on the current path and the next edge of it to examine.
+	type frame struct {
+		x           int
+		edgePointer *edge
+	}
+
+	enter := func(x int) (frame, Control) {
+		data.discovered[x] = true
+		control := pve(x, data)
 
-	data.time++
-	data.entryTime[x] = data.time
+		data.time++
+		data.entryTime[x] = data.time
 
-	edgePointer := g.edges[x]
+		if control == SKIP {
+			return frame{x: x}, CONTINUE
+		}
+		return frame{x: x, edgePointer: g.edges[x]}, control
+	}
+
+	first, control := enter(start)
+	if control == STOP {
+		return data
+	}
+	stack := []frame{first}
+
+	for len(stack) != 0 {
+		top := &stack[len(stack)-1]
+		x := top.x
+
+		if top.edgePointer == nil {
+			stack = stack[:len(stack)-1]
+
+			data.processed[x] = true
+			control := pvl(x, data)
+
+			data.exitTime[x] = data.time
+			data.time++
+
+			if control == STOP {
+				return data
+			}
+			continue
+		}
+
+		y := top.edgePointer.y
+		top.edgePointer = top.edgePointer.next
 
-	for edgePointer != nil {
-		y := edgePointer.y
 		if data.discovered[y] == false {
 			data.parent[y] = x
-			pe(x, y, data)
-			g.DepthFirstTraversal(y, pve, pvl, pe, data)
+			control := pe(x, y, data)
+			if control == STOP {
+				return data
+			}
+			if control == SKIP {
+				data.parent[y] = 0
+				continue
+			}
+			next, control := enter(y)
+			if control == STOP {
+				return data
+			}
+			stack = append(stack, next)
 			// The boolean expression for undirected graphs below is subtle.
 			// y is either an ancestor or a descendant. The processed check rules out
 			// descendant, and the parent check rules out parent ancestor. Only an edge to
 			// a non-parent ancestor should be processed, which would indicate a back edge.
 		} else if g.directed == true || (data.processed[y] == false && data.parent[x] != y) {
-			pe(x, y, data)
+			if pe(x, y, data) == STOP {
+				return data
+			}
 		}
-		edgePointer = edgePointer.next
 	}
 
-	data.processed[x] = true
-	pvl(x, data)
-
-	data.exitTime[x] = data.time
-	data.time++
-
 	return data
 }
 
 // ConnectedComponents returns the number of connected components in the graph.
+// Directed graphs are split into weakly connected components, as described by
+// ConnectedComponentLabels.
 func (g *Graph) ConnectedComponents() int {
+	count, _, _ := g.ConnectedComponentLabels()
+	return count
+}
+
+// ConnectedComponentLabels returns the number of connected components, which
+// component each vertex belongs to, and the number of vertices in each
+// component. Components are numbered from 1 and both slices are indexed
+// accordingly. Edge direction is ignored, so a directed graph is split into
+// its weakly connected components; StronglyConnectedComponents respects
+// direction.
+func (g *Graph) ConnectedComponentLabels() (int, []int, []int) {
 	if g.nVertices == 0 {
-		return 0
+		return 0, nil, nil
 	}
 
+	view := g.undirected()
 	data := &TraversalData{}
-	data.Init(g)
-	pve := func(v int, data *TraversalData) {}
-	pvl := func(v int, data *TraversalData) {}
-	pe := func(x int, y int, data *TraversalData) {}
+	data.Init(view)
 
 	count := 0
+	component := make([]int, adjustSize(g.nVertices))
+	sizes := []int{0}
+
+	pve := func(v int, data *TraversalData) Control {
+		component[v] = count
+		sizes[count]++
+		return CONTINUE
+	}
+	pvl := func(v int, data *TraversalData) Control { return CONTINUE }
+	pe := func(x int, y int, data *TraversalData) Control { return CONTINUE }
 
 	for i := 1; i <= g.nVertices; i++ {
 		if data.discovered[i] == false {
 			count++
-			g.BreadthFirstTraversal(i, pve, pvl, pe, data)
+			sizes = append(sizes, 0)
+			view.BreadthFirstTraversal(i, pve, pvl, pe, data)
 		}
 	}
 
-	return count
+	return count, component, sizes
+}
+
+// ComponentGraphs splits the graph into one graph per connected component, in
+// the order numbered by ConnectedComponentLabels. Alongside each graph is the
+// mapping from its vertices back to the original vertices, as returned by
+// InducedSubgraph. The graphs of a directed graph keep their edge directions.
+func (g *Graph) ComponentGraphs() ([]*Graph, [][]int) {
+	count, component, sizes := g.ConnectedComponentLabels()
+
+	members := make([][]int, adjustSize(count))
+	for c := 1; c <= count; c++ {
+		members[c] = make([]int, 0, sizes[c])
+	}
+	for v := 1; v <= g.nVertices; v++ {
+		members[component[v]] = append(members[component[v]], v)
+	}
+
+	graphs := make([]*Graph, 0, count)
+	mappings := make([][]int, 0, count)
+	for c := 1; c <= count; c++ {
+		sub, mapping := g.InducedSubgraph(members[c])
+		graphs = append(graphs, sub)
+		mappings = append(mappings, mapping)
+	}
+
+	return graphs, mappings
 }
 
 // Bipartite checks if the graph is bipartite.
@@ -202,22 +612,23 @@ func (g *Graph) Bipartite() bool {
 
 	data := &TraversalData{}
 	data.Init(g)
-	pve := func(v int, data *TraversalData) {}
-	pvl := func(v int, data *TraversalData) {}
+	pve := func(v int, data *TraversalData) Control { return CONTINUE }
+	pvl := func(v int, data *TraversalData) Control { return CONTINUE }
 
 	bipartite := true
 	color := make([]int, adjustSize(g.nVertices)) // 0 is uncolored.
 
-	pe := func(x int, y int, data *TraversalData) {
+	pe := func(x int, y int, data *TraversalData) Control {
 		if color[x] == color[y] {
 			bipartite = false
+			return STOP
+		}
+		if color[x] == 1 {
+			color[y] = 2
 		} else {
-			if color[x] == 1 {
-				color[y] = 2
-			} else {
-				color[y] = 1
-			}
+			color[y] = 1
 		}
+		return CONTINUE
 	}
 
 	for i := 1; i <= g.nVertices; i++ {
@@ -235,53 +646,127 @@ func (g *Graph) Bipartite() bool {
 
 // HasCycles checks if the graph has any cycles
 func (g *Graph) HasCycles() bool {
+	return g.FindCycle() != nil
+}
+
+// FindCycle returns the vertices of a cycle in edge order, or nil if the graph
+// is acyclic. The last vertex has an edge back to the first. Every component is
+// searched, and a self-loop is a cycle of one vertex.
+func (g *Graph) FindCycle() []int {
 	if g.nVertices == 0 {
-		return false
+		return nil
 	}
 
 	data := &TraversalData{}
 	data.Init(g)
-	pve := func(v int, data *TraversalData) {}
-	pvl := func(v int, data *TraversalData) {}
-
-	hasCycles := false
-
-	pe := func(x int, y int, data *TraversalData) {
-		if data.discovered[y] == true && data.parent[x] != y {
-			hasCycles = true
+	pve := func(v int, data *TraversalData) Control { return CONTINUE }
+	pvl := func(v int, data *TraversalData) Control { return CONTINUE }
+
+	var cycle []int
+
+	// Only a back edge closes a cycle. Tree edges reach undiscovered vertices,
+	// and forward and cross edges reach processed ones. Undirected traversals
+	// never report an edge back to the parent, which is not a cycle.
+	pe := func(x int, y int, data *TraversalData) Control {
+		if data.discovered[y] == true && data.processed[y] == false {
+			cycle = treePath(x, y, data)
+			return STOP
 		}
+		return CONTINUE
 	}
 
-	g.DepthFirstTraversal(1, pve, pvl, pe, data)
+	for i := 1; i <= g.nVertices && cycle == nil; i++ {
+		if data.discovered[i] == false {
+			g.DepthFirstTraversal(i, pve, pvl, pe, data)
+		}
+	}
 
-	return hasCycles
+	return cycle
 }
 
-// Determines the class of a given edge.
-func edgeClassification(x int, y int, data *TraversalData) EdgeClass {
-	if data.parent[y] == x {
-		return TREE
+// Determines the class of a given edge. Tree edges are processed before y is
+// discovered, so a parallel edge to a child that is already discovered is a
+// forward edge.
+func edgeClassification(x int, y int, data *TraversalData) (EdgeClass, error) {
+	if data.parent[y] == x && !data.discovered[y] {
+		return TREE, nil
 	}
 	if data.discovered[y] && !data.processed[y] {
-		return BACK
+		return BACK, nil
 	}
 	if data.processed[y] && (data.entryTime[y] > data.entryTime[x]) {
-		return FORWARD
+		return FORWARD, nil
 	}
 	if data.processed[y] && (data.entryTime[y] < data.entryTime[x]) {
-		return CROSS
+		return CROSS, nil
 	}
-	log.Fatalf("Cannot classify edge: %d, %d", x, y)
-	return -1
+	return -1, fmt.Errorf("graph: cannot classify edge %d, %d", x, y)
 }
 
-// ArticulationVertices returns a map of all cut-nodes.
-func (g *Graph) ArticulationVertices(start int) map[int]string {
-	cutNodes := make(map[int]string)
+// Returns the tree path from ancestor y down to x. Together with the back edge
+// (x, y) it forms a cycle.
+func treePath(x int, y int, data *TraversalData) []int {
+	cycle := []int{x}
+	for v := x; v != y; {
+		v = data.parent[v]
+		cycle = append(cycle, v)
+	}
+	for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+		cycle[i], cycle[j] = cycle[j], cycle[i]
+	}
+	return cycle
+}
+
+// ArticulationVertices returns a map of all cut-nodes in the connected
+// component of start. ErrDirected is returned for directed graphs.
+func (g *Graph) ArticulationVertices(start int) (map[int]CutNodeClass, error) {
+	if g.directed {
+		return nil, ErrDirected
+	}
 	if g.nVertices == 0 {
-		return cutNodes
+		return make(map[int]CutNodeClass), nil
+	}
+	if err := g.checkVertex(start); err != nil {
+		return nil, err
 	}
 
+	cutNodes, _, err := g.articulationVertices([]int{start})
+	return cutNodes, err
+}
+
+// CutNodes returns every cut-node in the graph, across all connected
+// components, in increasing order of vertex. ErrDirected is returned for
+// directed graphs.
+func (g *Graph) CutNodes() ([]CutNode, error) {
+	if g.directed {
+		return nil, ErrDirected
+	}
+
+	starts := make([]int, g.nVertices)
+	for i := range starts {
+		starts[i] = i + 1
+	}
+
+	classes, components, err := g.articulationVertices(starts)
+	if err != nil {
+		return nil, err
+	}
+
+	var cutNodes []CutNode
+	for v := 1; v <= g.nVertices; v++ {
+		if class, ok := classes[v]; ok {
+			cutNodes = append(cutNodes, CutNode{Vertex: v, Class: class, Components: components[v]})
+		}
+	}
+	return cutNodes, nil
+}
+
+// Finds the cut-nodes reachable from each of starts not yet discovered. Also
+// returns how many pieces each vertex's component splits into when it is
+// removed.
+func (g *Graph) articulationVertices(starts []int) (map[int]CutNodeClass, []int, error) {
+	cutNodes := make(map[int]CutNodeClass)
+
 	data := &TraversalData{}
 	data.Init(g)
 
@@ -290,19 +775,29 @@ func (g *Graph) ArticulationVertices(start int) map[int]string {
 	// outDegree is how many tree edges the vertex has to children. This is used
 	// when determining if a vertex is a root cut-node or a child bridge cut-node.
 	outDegree := make([]int, adjustSize(g.nVertices))
+	// components counts the pieces left behind by removing each vertex. Each
+	// child whose subtree reaches no higher than the vertex becomes a piece, as
+	// does the rest of the component above a non-root vertex.
+	components := make([]int, adjustSize(g.nVertices))
 
 	// Initializes earliest reachable ancestor to self.
-	pve := func(v int, data *TraversalData) {
+	pve := func(v int, data *TraversalData) Control {
 		ancestor[v] = v
+		return CONTINUE
 	}
 
 	// Marks vertices as cut-nodes based on various properties. Also potentially
 	// updates parent's earliest reachable ancestor.
-	pvl := func(v int, data *TraversalData) {
+	pvl := func(v int, data *TraversalData) Control {
 		if data.parent[v] == 0 { // Check if root.
 			if outDegree[v] > 1 { // Check if vertex has more than one child.
-				cutNodes[v] = "root"
-				return
+				cutNodes[v] = ROOT
+				return CONTINUE
+			}
+		} else {
+			components[v]++ // The rest of the component, above v.
+			if data.entryTime[ancestor[v]] >= data.entryTime[data.parent[v]] {
+				components[data.parent[v]]++
 			}
 		}
 
@@ -312,11 +807,11 @@ func (g *Graph) ArticulationVertices(start int) map[int]string {
 
 		if parentIsRoot == false {
 			if ancestor[v] == data.parent[v] {
-				cutNodes[data.parent[v]] = "parent"
+				cutNodes[data.parent[v]] = PARENT
 			} else if ancestor[v] == v {
-				cutNodes[data.parent[v]] = "bridge"
+				cutNodes[data.parent[v]] = BRIDGE
 				if outDegree[v] > 0 {
-					cutNodes[v] = "bridge"
+					cutNodes[v] = BRIDGE
 				}
 			}
 		}
@@ -327,17 +822,21 @@ func (g *Graph) ArticulationVertices(start int) map[int]string {
 		if timeV < timeParent {
 			ancestor[data.parent[v]] = ancestor[v]
 		}
+		return CONTINUE
 	}
 
 	// Updates either a vertex's out degree or earliest reachable ancestor value.
-	// The only case where no update happens for an undirected graph is when y is
-	// the parent of x. For directed graphs there is also no update in the case of
-	// a forward or cross edge.
-	pe := func(x int, y int, data *TraversalData) {
-		class := edgeClassification(x, y, data)
+	// The only case where no update happens is when y is the parent of x.
+	var err error
+	pe := func(x int, y int, data *TraversalData) Control {
+		class, classErr := edgeClassification(x, y, data)
+		if classErr != nil {
+			err = classErr
+			return STOP
+		}
 		if TREE == class {
 			outDegree[x]++
-			return
+			return CONTINUE
 		}
 		if BACK == class && data.parent[x] != y {
 			// Found back edge to ancestor y.
@@ -345,17 +844,29 @@ func (g *Graph) ArticulationVertices(start int) map[int]string {
 				ancestor[x] = y
 			}
 		}
+		return CONTINUE
 	}
 
-	g.DepthFirstTraversal(start, pve, pvl, pe, data)
+	for _, start := range starts {
+		if data.discovered[start] == false && err == nil {
+			g.DepthFirstTraversal(start, pve, pvl, pe, data)
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
 
-	return cutNodes
+	return cutNodes, components, nil
 }
 
-// TopologicalSort returns vertices sorted in topological order.
-func (g *Graph) TopologicalSort() []int {
+// TopologicalSort returns vertices sorted in reverse topological order, the
+// order a depth-first traversal finishes them, so every edge runs from a later
+// vertex to an earlier one. KahnTopologicalSort gives forward order.
+// ErrNotDirected is returned for undirected graphs, and a *CycleError for
+// graphs with a cycle.
+func (g *Graph) TopologicalSort() ([]int, error) {
 	if g.directed == false {
-		log.Fatal("Cannot call TopologicalSort on an undirected graph.")
+		return nil, ErrNotDirected
 	}
 
 	data := &TraversalData{}
@@ -363,30 +874,37 @@ func (g *Graph) TopologicalSort() []int {
 
 	sorted := make([]int, 0, g.nVertices)
 
-	pve := func(v int, data *TraversalData) {}
-	pvl := func(v int, data *TraversalData) {
+	pve := func(v int, data *TraversalData) Control { return CONTINUE }
+	pvl := func(v int, data *TraversalData) Control {
 		sorted = append(sorted, v)
+		return CONTINUE
 	}
-	pe := func(x int, y int, data *TraversalData) {
+	var err error
+	pe := func(x int, y int, data *TraversalData) Control {
 		if data.discovered[y] && !data.processed[y] {
-			log.Fatalf("Back edge found: %d, %d. Cannot perform a topological sort on a graph with cycles.", x, y)
+			err = &CycleError{Edge: Edge{X: x, Y: y}, Cycle: treePath(x, y, data)}
+			return STOP
 		}
+		return CONTINUE
 	}
 
-	for i := 1; i <= g.nVertices; i++ {
+	for i := 1; i <= g.nVertices && err == nil; i++ {
 		if data.discovered[i] == false {
 			g.DepthFirstTraversal(i, pve, pvl, pe, data)
 		}
 	}
+	if err != nil {
+		return nil, err
+	}
 
-	return sorted
+	return sorted, nil
 }
 
 // StronglyConnectedComponents returns data about strongly connected components
-// in a directed graph.
-func (g *Graph) StronglyConnectedComponents() (int, []int) {
+// in a directed graph. ErrNotDirected is returned for undirected graphs.
+func (g *Graph) StronglyConnectedComponents() (int, []int, error) {
 	if g.directed == false {
-		log.Fatal("Cannot call StronglyConnectedComponents on an undirected graph.")
+		return 0, nil, ErrNotDirected
 	}
 
 	data := &TraversalData{}
@@ -407,13 +925,14 @@ func (g *Graph) StronglyConnectedComponents() (int, []int) {
 	active := list.New()
 
 	// Add newly discovered vertex to the current scc.
-	pve := func(v int, data *TraversalData) {
+	pve := func(v int, data *TraversalData) Control {
 		active.PushFront(v)
+		return CONTINUE
 	}
 
 	// This serves two purposes. The first is to check if we are done processing a
 	// component. The second is to update parent's "low" value if necessary.
-	pvl := func(v int, data *TraversalData) {
+	pvl := func(v int, data *TraversalData) Control {
 		// If v itself is the oldest reachable vertex in a scc when we are about to
 		// back up from it, then this completes a scc.
 		if low[v] == v {
@@ -432,17 +951,23 @@ func (g *Graph) StronglyConnectedComponents() (int, []int) {
 				low[data.parent[v]] = low[v]
 			}
 		}
+		return CONTINUE
 	}
 
 	// Examine edges in order to update "low" value for x. Only back or cross
 	// edges may impact this value.
-	pe := func(x int, y int, data *TraversalData) {
-		class := edgeClassification(x, y, data)
+	var err error
+	pe := func(x int, y int, data *TraversalData) Control {
+		class, classErr := edgeClassification(x, y, data)
+		if classErr != nil {
+			err = classErr
+			return STOP
+		}
 		if BACK == class {
 			if data.entryTime[y] < data.entryTime[low[x]] {
 				low[x] = y
 			}
-			return
+			return CONTINUE
 		}
 		if CROSS == class {
 			if scc[y] == 0 {
@@ -451,13 +976,61 @@ func (g *Graph) StronglyConnectedComponents() (int, []int) {
 				}
 			}
 		}
+		return CONTINUE
 	}
 
-	for i := 1; i <= g.nVertices; i++ {
+	for i := 1; i <= g.nVertices && err == nil; i++ {
 		if data.discovered[i] == false {
 			g.DepthFirstTraversal(i, pve, pvl, pe, data)
 		}
 	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return componentCount, scc, nil
+}
+
+// Condensation returns the condensation of a directed graph: a new directed
+// graph with a vertex for each strongly connected component, numbered as by
+// StronglyConnectedComponents, and an edge between two components wherever the
+// original graph has an edge between their members. Parallel edges and edges
+// within a component are left out, and every edge has a weight of 1. The
+// result is acyclic, so it can be sorted topologically. Components are
+// numbered in the order they are completed, so every edge runs from a higher
+// numbered component to a lower one.
+//
+// The returned members give the vertices of each component in increasing
+// order. Index 0 is unused. ErrNotDirected is returned for undirected graphs.
+func (g *Graph) Condensation() (*Graph, [][]int, error) {
+	count, scc, err := g.StronglyConnectedComponents()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members := make([][]int, adjustSize(count))
+	for v := 1; v <= g.nVertices; v++ {
+		members[scc[v]] = append(members[scc[v]], v)
+	}
+
+	dag := &Graph{
+		directed:  true,
+		edges:     make([]*edge, adjustSize(count)),
+		nVertices: count,
+	}
+	// The last component given an edge to each component.
+	seen := make([]int, adjustSize(count))
+	for x := 1; x <= count; x++ {
+		for _, v := range members[x] {
+			for edgePointer := g.edges[v]; edgePointer != nil; edgePointer = edgePointer.next {
+				y := scc[edgePointer.y]
+				if y != x && seen[y] != x {
+					seen[y] = x
+					dag.insertEdge(true, x, y, 1)
+				}
+			}
+		}
+	}
 
-	return componentCount, scc
+	return dag, members, nil
 }
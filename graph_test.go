@@ -1,6 +1,10 @@
 package graph
 
-import "testing"
+import (
+	"errors"
+	"runtime/debug"
+	"testing"
+)
 
 func TestUndirectedInit(t *testing.T) {
 	graph := &Graph{}
@@ -46,6 +50,45 @@ func TestDirectedInit(t *testing.T) {
 	}
 }
 
+func TestInit_oddEdgeList(t *testing.T) {
+	graph := &Graph{}
+
+	if err := graph.Init(false, []int{1, 2, 3}); err != ErrOddEdgeList {
+		t.Errorf("err should be ErrOddEdgeList, got %v", err)
+	}
+}
+
+func TestInit_outOfRange(t *testing.T) {
+	graph := &Graph{}
+
+	err := graph.Init(false, []int{1, 100})
+	if !errors.Is(err, ErrUnknownVertex) {
+		t.Errorf("err should wrap ErrUnknownVertex, got %v", err)
+	}
+	if graph.nVertices != 0 {
+		t.Errorf("graph.nVertices should be 0, got %v", graph.nVertices)
+	}
+}
+
+func TestWeightedInit(t *testing.T) {
+	edgeList := []int{1, 2, 2, 3}
+	weights := []float64{2.5, 4}
+
+	graph := &Graph{}
+	graph.InitWeighted(false, edgeList, weights)
+
+	if graph.nEdges != 2 {
+		t.Errorf("graph.nEdges should be 2, got %v", graph.nEdges)
+	}
+	if graph.edges[1].weight != 2.5 {
+		t.Errorf("graph.edges[1].weight should be 2.5, got %v", graph.edges[1].weight)
+	}
+	// The mirrored entry of an undirected edge shares its weight.
+	if graph.edges[3].weight != 4 {
+		t.Errorf("graph.edges[3].weight should be 4, got %v", graph.edges[3].weight)
+	}
+}
+
 func TestBreadthFirstTraversal_Undirected(t *testing.T) {
 	edgeList := []int{
 		1, 2,
@@ -57,11 +100,12 @@ func TestBreadthFirstTraversal_Undirected(t *testing.T) {
 	graph.Init(false, edgeList)
 
 	var order []int
-	processVertexEarly := func(v int, data *TraversalData) {
+	processVertexEarly := func(v int, data *TraversalData) Control {
 		order = append(order, v)
+		return CONTINUE
 	}
-	processVertexLate := func(v int, data *TraversalData) {}
-	processEdge := func(x int, y int, data *TraversalData) {}
+	processVertexLate := func(v int, data *TraversalData) Control { return CONTINUE }
+	processEdge := func(x int, y int, data *TraversalData) Control { return CONTINUE }
 
 	data := &TraversalData{}
 	data.Init(graph)
@@ -69,7 +113,7 @@ func TestBreadthFirstTraversal_Undirected(t *testing.T) {
 
 	for i := 1; i < graph.nVertices+1; i++ {
 		if result.processed[i] != true {
-			t.Errorf("result.processed["+string(i)+"] should be true, got %v", result.processed[i])
+			t.Errorf("result.processed[%v] should be true, got %v", i, result.processed[i])
 		}
 	}
 
@@ -112,11 +156,12 @@ func TestDepthFirstTraversal_Undirected(t *testing.T) {
 	graph.Init(false, edgeList)
 
 	var order []int
-	processVertexEarly := func(v int, data *TraversalData) {
+	processVertexEarly := func(v int, data *TraversalData) Control {
 		order = append(order, v)
+		return CONTINUE
 	}
-	processVertexLate := func(v int, data *TraversalData) {}
-	processEdge := func(x int, y int, data *TraversalData) {}
+	processVertexLate := func(v int, data *TraversalData) Control { return CONTINUE }
+	processEdge := func(x int, y int, data *TraversalData) Control { return CONTINUE }
 
 	data := &TraversalData{}
 	data.Init(graph)
@@ -124,7 +169,7 @@ func TestDepthFirstTraversal_Undirected(t *testing.T) {
 
 	for i := 1; i < graph.nVertices+1; i++ {
 		if result.processed[i] != true {
-			t.Errorf("result.processed["+string(i)+"] should be true, got %v", result.processed[i])
+			t.Errorf("result.processed[%v] should be true, got %v", i, result.processed[i])
 		}
 	}
 
@@ -167,11 +212,12 @@ func TestBreadthFirstTraversal_Directed(t *testing.T) {
 	graph.Init(true, edgeList)
 
 	var order []int
-	processVertexEarly := func(v int, data *TraversalData) {
+	processVertexEarly := func(v int, data *TraversalData) Control {
 		order = append(order, v)
+		return CONTINUE
 	}
-	processVertexLate := func(v int, data *TraversalData) {}
-	processEdge := func(x int, y int, data *TraversalData) {}
+	processVertexLate := func(v int, data *TraversalData) Control { return CONTINUE }
+	processEdge := func(x int, y int, data *TraversalData) Control { return CONTINUE }
 
 	data := &TraversalData{}
 	data.Init(graph)
@@ -179,7 +225,7 @@ func TestBreadthFirstTraversal_Directed(t *testing.T) {
 
 	for i := 1; i < graph.nVertices+1; i++ {
 		if result.processed[i] != true {
-			t.Errorf("result.processed["+string(i)+"] should be true, got %v", result.processed[i])
+			t.Errorf("result.processed[%v] should be true, got %v", i, result.processed[i])
 		}
 	}
 
@@ -222,11 +268,12 @@ func TestDepthFirstTraversal_Directed(t *testing.T) {
 	graph.Init(true, edgeList)
 
 	var order []int
-	processVertexEarly := func(v int, data *TraversalData) {
+	processVertexEarly := func(v int, data *TraversalData) Control {
 		order = append(order, v)
+		return CONTINUE
 	}
-	processVertexLate := func(v int, data *TraversalData) {}
-	processEdge := func(x int, y int, data *TraversalData) {}
+	processVertexLate := func(v int, data *TraversalData) Control { return CONTINUE }
+	processEdge := func(x int, y int, data *TraversalData) Control { return CONTINUE }
 
 	data := &TraversalData{}
 	data.Init(graph)
@@ -234,7 +281,7 @@ func TestDepthFirstTraversal_Directed(t *testing.T) {
 
 	for i := 1; i < graph.nVertices+1; i++ {
 		if result.processed[i] != true {
-			t.Errorf("result.processed["+string(i)+"] should be true, got %v", result.processed[i])
+			t.Errorf("result.processed[%v] should be true, got %v", i, result.processed[i])
 		}
 	}
 
@@ -266,6 +313,248 @@ func TestDepthFirstTraversal_Directed(t *testing.T) {
 	}
 }
 
+func TestBreadthFirstTraversal_stop(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		1, 3,
+		3, 4,
+	}
+
+	graph := &Graph{}
+	graph.Init(false, edgeList)
+
+	var order []int
+	processVertexEarly := func(v int, data *TraversalData) Control {
+		order = append(order, v)
+		if v == 3 {
+			return STOP
+		}
+		return CONTINUE
+	}
+	processVertexLate := func(v int, data *TraversalData) Control { return CONTINUE }
+	processEdge := func(x int, y int, data *TraversalData) Control { return CONTINUE }
+
+	data := &TraversalData{}
+	data.Init(graph)
+	graph.BreadthFirstTraversal(1, processVertexEarly, processVertexLate, processEdge, data)
+
+	if len(order) != 2 {
+		t.Errorf("len(order) should be 2, got %v", len(order))
+	}
+	if data.discovered[4] != false {
+		t.Errorf("data.discovered[4] should be false, got %v", data.discovered[4])
+	}
+}
+
+func TestBreadthFirstTraversal_skip(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		2, 3,
+		3, 4,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	// Limit the search to a depth of 1.
+	depth := make([]int, graph.nVertices+1)
+	processVertexEarly := func(v int, data *TraversalData) Control {
+		if data.parent[v] != 0 {
+			depth[v] = depth[data.parent[v]] + 1
+		}
+		if depth[v] == 1 {
+			return SKIP
+		}
+		return CONTINUE
+	}
+	processVertexLate := func(v int, data *TraversalData) Control { return CONTINUE }
+	processEdge := func(x int, y int, data *TraversalData) Control { return CONTINUE }
+
+	data := &TraversalData{}
+	data.Init(graph)
+	graph.BreadthFirstTraversal(1, processVertexEarly, processVertexLate, processEdge, data)
+
+	if data.processed[2] != true {
+		t.Errorf("data.processed[2] should be true, got %v", data.processed[2])
+	}
+	if data.discovered[3] != false {
+		t.Errorf("data.discovered[3] should be false, got %v", data.discovered[3])
+	}
+}
+
+func TestDepthFirstTraversal_skip(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		1, 3,
+		3, 4,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	var late []int
+	processVertexEarly := func(v int, data *TraversalData) Control { return CONTINUE }
+	processVertexLate := func(v int, data *TraversalData) Control {
+		late = append(late, v)
+		return CONTINUE
+	}
+	// Prune the subtree below 3.
+	processEdge := func(x int, y int, data *TraversalData) Control {
+		if y == 3 {
+			return SKIP
+		}
+		return CONTINUE
+	}
+
+	data := &TraversalData{}
+	data.Init(graph)
+	graph.DepthFirstTraversal(1, processVertexEarly, processVertexLate, processEdge, data)
+
+	if data.discovered[3] != false || data.discovered[4] != false {
+		t.Errorf("3 and 4 should be undiscovered, got %v", data.discovered)
+	}
+	if data.parent[3] != 0 {
+		t.Errorf("data.parent[3] should be 0, got %v", data.parent[3])
+	}
+	if len(late) != 2 || late[0] != 2 || late[1] != 1 {
+		t.Errorf("late should be [2 1], got %v", late)
+	}
+}
+
+func TestDepthFirstTraversal_stop(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		1, 3,
+		3, 4,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	processVertexEarly := func(v int, data *TraversalData) Control {
+		if v == 4 {
+			return STOP
+		}
+		return CONTINUE
+	}
+	processVertexLate := func(v int, data *TraversalData) Control { return CONTINUE }
+	processEdge := func(x int, y int, data *TraversalData) Control { return CONTINUE }
+
+	data := &TraversalData{}
+	data.Init(graph)
+	graph.DepthFirstTraversal(1, processVertexEarly, processVertexLate, processEdge, data)
+
+	// 3 is at the head of 1's edge list, so 2 is never reached.
+	if data.discovered[2] != false {
+		t.Errorf("data.discovered[2] should be false, got %v", data.discovered[2])
+	}
+	if data.processed[1] != false {
+		t.Errorf("data.processed[1] should be false, got %v", data.processed[1])
+	}
+}
+
+func TestTraversalData_PathTo(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		1, 3,
+		3, 4,
+		5, 6,
+	}
+
+	graph := &Graph{}
+	graph.Init(false, edgeList)
+
+	pve := func(v int, data *TraversalData) Control { return CONTINUE }
+	pvl := func(v int, data *TraversalData) Control { return CONTINUE }
+	pe := func(x int, y int, data *TraversalData) Control { return CONTINUE }
+
+	data := &TraversalData{}
+	data.Init(graph)
+	graph.BreadthFirstTraversal(1, pve, pvl, pe, data)
+
+	path := data.PathTo(4)
+	if len(path) != 3 || path[0] != 1 || path[1] != 3 || path[2] != 4 {
+		t.Errorf("data.PathTo(4) should be [1 3 4], got %v", path)
+	}
+	if data.Depth(4) != 2 {
+		t.Errorf("data.Depth(4) should be 2, got %v", data.Depth(4))
+	}
+	if data.PathTo(5) != nil {
+		t.Errorf("data.PathTo(5) should be nil, got %v", data.PathTo(5))
+	}
+	if data.Depth(5) != -1 {
+		t.Errorf("data.Depth(5) should be -1, got %v", data.Depth(5))
+	}
+	if data.Parent(4) != 3 {
+		t.Errorf("data.Parent(4) should be 3, got %v", data.Parent(4))
+	}
+}
+
+func TestTraversalData_IsAncestor(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		1, 3,
+		3, 4,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	pve := func(v int, data *TraversalData) Control { return CONTINUE }
+	pvl := func(v int, data *TraversalData) Control { return CONTINUE }
+	pe := func(x int, y int, data *TraversalData) Control { return CONTINUE }
+
+	data := &TraversalData{}
+	data.Init(graph)
+	graph.DepthFirstTraversal(1, pve, pvl, pe, data)
+
+	if data.IsAncestor(1, 4) != true {
+		t.Errorf("data.IsAncestor(1, 4) should be true, got false")
+	}
+	if data.IsAncestor(3, 4) != true {
+		t.Errorf("data.IsAncestor(3, 4) should be true, got false")
+	}
+	if data.IsAncestor(2, 4) != false {
+		t.Errorf("data.IsAncestor(2, 4) should be false, got true")
+	}
+	if data.IsAncestor(4, 3) != false {
+		t.Errorf("data.IsAncestor(4, 3) should be false, got true")
+	}
+}
+
+func TestTraversalData_Reset(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		3, 4,
+	}
+
+	graph := &Graph{}
+	graph.Init(false, edgeList)
+
+	pve := func(v int, data *TraversalData) Control { return CONTINUE }
+	pvl := func(v int, data *TraversalData) Control { return CONTINUE }
+	pe := func(x int, y int, data *TraversalData) Control { return CONTINUE }
+
+	data := &TraversalData{}
+	data.Init(graph)
+	graph.DepthFirstTraversal(1, pve, pvl, pe, data)
+	data.Reset()
+	graph.DepthFirstTraversal(3, pve, pvl, pe, data)
+
+	if data.Discovered(1) != false {
+		t.Errorf("data.Discovered(1) should be false, got true")
+	}
+	if data.Processed(4) != true {
+		t.Errorf("data.Processed(4) should be true, got false")
+	}
+	if data.EntryTime(3) != 1 {
+		t.Errorf("data.EntryTime(3) should be 1, got %v", data.EntryTime(3))
+	}
+	if data.ExitTime(3) != 3 {
+		t.Errorf("data.ExitTime(3) should be 3, got %v", data.ExitTime(3))
+	}
+}
+
 func TestConnectedComponents_oneComponent(t *testing.T) {
 	edgeList := []int{
 		1, 2,
@@ -297,6 +586,98 @@ func TestConnectedComponents_twoComponents(t *testing.T) {
 	}
 }
 
+func TestConnectedComponentLabels(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		3, 4,
+		4, 5,
+		2, 6,
+	}
+
+	graph := &Graph{}
+	graph.Init(false, edgeList)
+
+	count, component, sizes := graph.ConnectedComponentLabels()
+
+	if count != 2 {
+		t.Errorf("count should be 2, got %v", count)
+	}
+	if component[1] != 1 || component[2] != 1 || component[6] != 1 {
+		t.Errorf("vertices 1, 2 and 6 should be in component 1, got %v", component)
+	}
+	if component[3] != 2 || component[4] != 2 || component[5] != 2 {
+		t.Errorf("vertices 3, 4 and 5 should be in component 2, got %v", component)
+	}
+	if sizes[1] != 3 {
+		t.Errorf("sizes[1] should be 3, got %v", sizes[1])
+	}
+	if sizes[2] != 3 {
+		t.Errorf("sizes[2] should be 3, got %v", sizes[2])
+	}
+}
+
+func TestComponentGraphs(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		3, 4,
+		4, 5,
+		5, 3,
+	}
+	weights := []float64{1, 2, 3, 4}
+
+	graph := &Graph{}
+	graph.InitWeighted(false, edgeList, weights)
+
+	graphs, mappings := graph.ComponentGraphs()
+
+	if len(graphs) != 2 {
+		t.Fatalf("len(graphs) should be 2, got %v", len(graphs))
+	}
+
+	triangle, mapping := graphs[1], mappings[1]
+	if triangle.nVertices != 3 {
+		t.Errorf("triangle.nVertices should be 3, got %v", triangle.nVertices)
+	}
+	if triangle.nEdges != 3 {
+		t.Errorf("triangle.nEdges should be 3, got %v", triangle.nEdges)
+	}
+	if mapping[1] != 3 || mapping[2] != 4 || mapping[3] != 5 {
+		t.Errorf("mapping should be [0 3 4 5], got %v", mapping)
+	}
+	// Edge 5-3 with weight 4 becomes 3-1, and stays at the head of 1's list.
+	if triangle.edges[1].y != 3 || triangle.edges[1].weight != 4 {
+		t.Errorf("triangle.edges[1] should be 3 with weight 4, got %v with weight %v", triangle.edges[1].y, triangle.edges[1].weight)
+	}
+}
+
+func TestComponentGraphs_directed(t *testing.T) {
+	// 1 cannot reach 2, but they are still weakly connected.
+	edgeList := []int{
+		2, 1,
+		3, 4,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	if count := graph.ConnectedComponents(); count != 2 {
+		t.Errorf("count should be 2, got %v", count)
+	}
+
+	graphs, mappings := graph.ComponentGraphs()
+	if len(graphs) != 2 {
+		t.Fatalf("len(graphs) should be 2, got %v", len(graphs))
+	}
+	first := graphs[0]
+	if first.nVertices != 2 || first.nEdges != 1 {
+		t.Errorf("first should have 2 vertices and 1 edge, got %v and %v", first.nVertices, first.nEdges)
+	}
+	// Vertex 1 of the component is 1, and vertex 2 is 2.
+	if mappings[0][1] != 1 || !first.HasEdge(2, 1) || first.HasEdge(1, 2) {
+		t.Errorf("first should keep the edge 2, 1 in its direction, got mapping %v", mappings[0])
+	}
+}
+
 func TestBipartite_true(t *testing.T) {
 	edgeList := []int{
 		1, 2,
@@ -355,6 +736,103 @@ func TestHasCycles_true(t *testing.T) {
 	}
 }
 
+func TestHasCycles_otherComponent(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		3, 4,
+		4, 5,
+		5, 3,
+	}
+
+	graph := &Graph{}
+	graph.Init(false, edgeList)
+
+	if graph.HasCycles() != true {
+		t.Errorf("graph.HasCycles() should be true, got %v", graph.HasCycles())
+	}
+}
+
+func TestHasCycles_directedAcyclic(t *testing.T) {
+	// A diamond is a cycle when undirected, but not when directed.
+	edgeList := []int{
+		1, 2,
+		1, 3,
+		2, 4,
+		3, 4,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	if graph.HasCycles() != false {
+		t.Errorf("graph.HasCycles() should be false, got %v", graph.HasCycles())
+	}
+}
+
+func TestFindCycle_directed(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		3, 4,
+		4, 5,
+		5, 3,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	cycle := graph.FindCycle()
+
+	if len(cycle) != 3 {
+		t.Fatalf("len(cycle) should be 3, got %v", cycle)
+	}
+	for i, v := range cycle {
+		w := cycle[(i+1)%len(cycle)]
+		if !graph.HasEdge(v, w) {
+			t.Errorf("cycle %v uses missing edge %d, %d", cycle, v, w)
+		}
+	}
+}
+
+func TestFindCycle_undirected(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		2, 3,
+		3, 4,
+		4, 2,
+	}
+
+	graph := &Graph{}
+	graph.Init(false, edgeList)
+
+	cycle := graph.FindCycle()
+
+	if len(cycle) != 3 {
+		t.Fatalf("len(cycle) should be 3, got %v", cycle)
+	}
+	for i, v := range cycle {
+		w := cycle[(i+1)%len(cycle)]
+		if !graph.HasEdge(v, w) {
+			t.Errorf("cycle %v uses missing edge %d, %d", cycle, v, w)
+		}
+	}
+}
+
+func TestFindCycle_selfLoop(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		2, 2,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	cycle := graph.FindCycle()
+
+	if len(cycle) != 1 || cycle[0] != 2 {
+		t.Errorf("cycle should be [2], got %v", cycle)
+	}
+}
+
 func TestArticulationVertices_root(t *testing.T) {
 	edgeList := []int{
 		1, 2,
@@ -364,12 +842,15 @@ func TestArticulationVertices_root(t *testing.T) {
 	graph := &Graph{}
 	graph.Init(false, edgeList)
 
-	cutNodes := graph.ArticulationVertices(1)
+	cutNodes, err := graph.ArticulationVertices(1)
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
 
 	if len(cutNodes) != 1 {
 		t.Errorf("len(cutNodes) should be 1, got %v", len(cutNodes))
 	}
-	if cutNodes[1] != "root" {
+	if cutNodes[1] != ROOT {
 		t.Errorf("cutNodes[1] should be root, got %v", cutNodes[1])
 	}
 }
@@ -385,12 +866,15 @@ func TestArticulationVertices_parent(t *testing.T) {
 	graph := &Graph{}
 	graph.Init(false, edgeList)
 
-	cutNodes := graph.ArticulationVertices(1)
+	cutNodes, err := graph.ArticulationVertices(1)
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
 
 	if len(cutNodes) != 1 {
 		t.Errorf("len(cutNodes) should be 1, got %v", len(cutNodes))
 	}
-	if cutNodes[2] != "parent" {
+	if cutNodes[2] != PARENT {
 		t.Errorf("cutNodes[2] should be parent, got %v", cutNodes[2])
 	}
 }
@@ -404,16 +888,95 @@ func TestArticulationVertices_bridge(t *testing.T) {
 	graph := &Graph{}
 	graph.Init(false, edgeList)
 
-	cutNodes := graph.ArticulationVertices(1)
+	cutNodes, err := graph.ArticulationVertices(1)
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
 
 	if len(cutNodes) != 1 {
 		t.Errorf("len(cutNodes) should be 1, got %v", len(cutNodes))
 	}
-	if cutNodes[2] != "bridge" {
+	if cutNodes[2] != BRIDGE {
 		t.Errorf("cutNodes[2] should be bridge, got %v", cutNodes[2])
 	}
 }
 
+func TestCutNodes(t *testing.T) {
+	// A path, and a star in a separate component.
+	edgeList := []int{
+		1, 2,
+		2, 3,
+		4, 5,
+		4, 6,
+		4, 7,
+	}
+
+	graph := &Graph{}
+	graph.Init(false, edgeList)
+
+	cutNodes, err := graph.CutNodes()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	expected := []CutNode{
+		{Vertex: 2, Class: BRIDGE, Components: 2},
+		{Vertex: 4, Class: ROOT, Components: 3},
+	}
+	if len(cutNodes) != len(expected) {
+		t.Fatalf("cutNodes should be %v, got %v", expected, cutNodes)
+	}
+	for i := range expected {
+		if cutNodes[i] != expected[i] {
+			t.Errorf("cutNodes should be %v, got %v", expected, cutNodes)
+			break
+		}
+	}
+}
+
+func TestCutNodes_components(t *testing.T) {
+	// Two triangles sharing vertex 3, with a pendant vertex 6 on 3.
+	edgeList := []int{
+		1, 2,
+		2, 3,
+		3, 1,
+		3, 4,
+		4, 5,
+		5, 3,
+		3, 6,
+	}
+
+	graph := &Graph{}
+	graph.Init(false, edgeList)
+
+	cutNodes, err := graph.CutNodes()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if len(cutNodes) != 1 {
+		t.Fatalf("len(cutNodes) should be 1, got %v", cutNodes)
+	}
+	if cutNodes[0].Vertex != 3 {
+		t.Errorf("cutNodes[0].Vertex should be 3, got %v", cutNodes[0].Vertex)
+	}
+	if cutNodes[0].Components != 3 {
+		t.Errorf("cutNodes[0].Components should be 3, got %v", cutNodes[0].Components)
+	}
+}
+
+func TestCutNodes_directed(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(true, []int{2, 1, 2, 3})
+
+	if _, err := graph.CutNodes(); err != ErrDirected {
+		t.Errorf("err should be ErrDirected, got %v", err)
+	}
+	if _, err := graph.ArticulationVertices(2); err != ErrDirected {
+		t.Errorf("err should be ErrDirected, got %v", err)
+	}
+}
+
 func TestTopologicalSort(t *testing.T) {
 	edgeList := []int{
 		1, 2,
@@ -425,7 +988,10 @@ func TestTopologicalSort(t *testing.T) {
 	graph := &Graph{}
 	graph.Init(true, edgeList)
 
-	sorted := graph.TopologicalSort()
+	sorted, err := graph.TopologicalSort()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
 
 	if sorted[0] != 5 {
 		t.Errorf("sorted[0] should be 5, got %v", sorted[0])
@@ -444,6 +1010,47 @@ func TestTopologicalSort(t *testing.T) {
 	}
 }
 
+func TestTopologicalSort_cycle(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		2, 3,
+		3, 4,
+		4, 2,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	_, err := graph.TopologicalSort()
+	if !errors.Is(err, ErrCycle) {
+		t.Fatalf("err should match ErrCycle, got %v", err)
+	}
+
+	cycleErr := err.(*CycleError)
+	if cycleErr.Edge != (Edge{X: 4, Y: 2}) {
+		t.Errorf("cycleErr.Edge should be {4 2 0}, got %v", cycleErr.Edge)
+	}
+	expected := []int{2, 3, 4}
+	if len(cycleErr.Cycle) != len(expected) {
+		t.Fatalf("cycleErr.Cycle should be %v, got %v", expected, cycleErr.Cycle)
+	}
+	for i := range expected {
+		if cycleErr.Cycle[i] != expected[i] {
+			t.Errorf("cycleErr.Cycle should be %v, got %v", expected, cycleErr.Cycle)
+			break
+		}
+	}
+}
+
+func TestTopologicalSort_undirected(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(false, []int{1, 2})
+
+	if _, err := graph.TopologicalSort(); err != ErrNotDirected {
+		t.Errorf("err should be ErrNotDirected, got %v", err)
+	}
+}
+
 func TestStronglyConnectedComponents(t *testing.T) {
 	edgeList := []int{
 		1, 2,
@@ -455,7 +1062,10 @@ func TestStronglyConnectedComponents(t *testing.T) {
 	graph := &Graph{}
 	graph.Init(true, edgeList)
 
-	componentCount, strongComponent := graph.StronglyConnectedComponents()
+	componentCount, strongComponent, err := graph.StronglyConnectedComponents()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
 
 	if componentCount != 2 {
 		t.Errorf("componentCount should be 2, got %v", componentCount)
@@ -492,7 +1102,10 @@ func TestStronglyConnectedComponents_withCrossEdge(t *testing.T) {
 	graph := &Graph{}
 	graph.Init(true, edgeList)
 
-	componentCount, strongComponent := graph.StronglyConnectedComponents()
+	componentCount, strongComponent, err := graph.StronglyConnectedComponents()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
 
 	if componentCount != 3 {
 		t.Errorf("componentCount should be 3, got %v", componentCount)
@@ -519,3 +1132,97 @@ func TestStronglyConnectedComponents_withCrossEdge(t *testing.T) {
 	}
 
 }
+
+func TestStronglyConnectedComponents_undirected(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(false, []int{1, 2})
+
+	if _, _, err := graph.StronglyConnectedComponents(); err != ErrNotDirected {
+		t.Errorf("err should be ErrNotDirected, got %v", err)
+	}
+}
+
+func TestCondensation(t *testing.T) {
+	// Two cycles, {2, 3} and {4, 5}, joined by two parallel paths, with 1 in
+	// front and 6 behind.
+	edgeList := []int{
+		1, 2,
+		2, 3,
+		3, 2,
+		2, 4,
+		3, 5,
+		4, 5,
+		5, 4,
+		5, 6,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	dag, members, err := graph.Condensation()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if dag.nVertices != 4 {
+		t.Errorf("dag.nVertices should be 4, got %v", dag.nVertices)
+	}
+	if dag.nEdges != 3 {
+		t.Errorf("dag.nEdges should be 3, got %v", dag.nEdges)
+	}
+
+	_, scc, _ := graph.StronglyConnectedComponents()
+	for c := 1; c < len(members); c++ {
+		for _, v := range members[c] {
+			if scc[v] != c {
+				t.Errorf("members[%v] should only hold component %v, got %v", c, c, members[c])
+			}
+		}
+	}
+	if len(members[scc[2]]) != 2 || len(members[scc[4]]) != 2 {
+		t.Errorf("members should pair 2, 3 and 4, 5, got %v", members)
+	}
+	if !dag.HasEdge(scc[2], scc[4]) {
+		t.Errorf("dag should have an edge from %v to %v", scc[2], scc[4])
+	}
+
+	if _, err := dag.TopologicalSort(); err != nil {
+		t.Errorf("err should be nil, got %v", err)
+	}
+}
+
+func TestCondensation_undirected(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(false, []int{1, 2})
+
+	if _, _, err := graph.Condensation(); err != ErrNotDirected {
+		t.Errorf("err should be ErrNotDirected, got %v", err)
+	}
+}
+
+func TestDepthFirstTraversal_deepChain(t *testing.T) {
+	// Deep enough to overflow a small stack if each vertex took a call frame.
+	// Go stacks grow up to 1 GB by default, which a recursive traversal would
+	// not reach, so the limit is lowered for the test.
+	defer debug.SetMaxStack(debug.SetMaxStack(16 << 20))
+	const length = 1000000
+	edgeList := make([]int, 0, 2*(length-1))
+	for v := 1; v < length; v++ {
+		edgeList = append(edgeList, v, v+1)
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	sorted, err := graph.TopologicalSort()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if sorted[0] != length {
+		t.Errorf("sorted[0] should be %v, got %v", length, sorted[0])
+	}
+	if sorted[length-1] != 1 {
+		t.Errorf("sorted[%v] should be 1, got %v", length-1, sorted[length-1])
+	}
+}
@@ -0,0 +1,277 @@
+package graph
+
+import "math"
+
+// KeyedGraph is a Graph whose vertices are identified by arbitrary comparable
+// keys, such as strings or database IDs, instead of the integers 1 to n. Keys
+// are mapped to vertex numbers in order of first appearance, and results are
+// translated back to keys. Algorithms without a keyed counterpart can be run on
+// Graph, with Key translating their results.
+type KeyedGraph[K comparable] struct {
+	graph Graph
+	index map[K]int // The vertex number of each key.
+	keys  []K       // The key of each vertex number. Index 0 is unused.
+}
+
+// Init initializes the graph. Every edge is given a weight of 1.
+// ErrOddEdgeList is returned if the last edge is missing a key.
+func (kg *KeyedGraph[K]) Init(directed bool, edgeList []K) error {
+	return kg.InitWeighted(directed, edgeList, nil)
+}
+
+// InitWeighted initializes the graph with a weight for each edge, in the same
+// form as Graph.InitWeighted.
+func (kg *KeyedGraph[K]) InitWeighted(directed bool, edgeList []K, weights []float64) error {
+	index := make(map[K]int)
+	keys := make([]K, 1)
+	vertexList := make([]int, len(edgeList))
+	for i, key := range edgeList {
+		v, ok := index[key]
+		if !ok {
+			keys = append(keys, key)
+			v = len(keys) - 1
+			index[key] = v
+		}
+		vertexList[i] = v
+	}
+	if err := kg.graph.InitWeighted(directed, vertexList, weights); err != nil {
+		return err
+	}
+	kg.index = index
+	kg.keys = keys
+	return nil
+}
+
+// Graph returns the underlying graph, whose vertices are numbered 1 to n.
+func (kg *KeyedGraph[K]) Graph() *Graph {
+	return &kg.graph
+}
+
+// Vertex returns the vertex number of key, and whether key is in the graph.
+func (kg *KeyedGraph[K]) Vertex(key K) (int, bool) {
+	v, ok := kg.index[key]
+	return v, ok
+}
+
+// Key returns the key of vertex number v.
+func (kg *KeyedGraph[K]) Key(v int) K {
+	return kg.keys[v]
+}
+
+// AddVertex adds key to the graph as an isolated vertex, unless it is already
+// present, and returns its vertex number.
+func (kg *KeyedGraph[K]) AddVertex(key K) int {
+	if v, ok := kg.index[key]; ok {
+		return v
+	}
+	if kg.index == nil {
+		kg.index = make(map[K]int)
+		kg.keys = make([]K, 1)
+	}
+	v := kg.graph.AddVertex()
+	kg.index[key] = v
+	kg.keys = append(kg.keys, key)
+	return v
+}
+
+// AddEdge adds an edge from x to y with a weight of 1, adding either key as a
+// vertex first if needed.
+func (kg *KeyedGraph[K]) AddEdge(x K, y K) {
+	kg.AddWeightedEdge(x, y, 1)
+}
+
+// AddWeightedEdge is AddEdge with the given weight.
+func (kg *KeyedGraph[K]) AddWeightedEdge(x K, y K, weight float64) {
+	vx, vy := kg.AddVertex(x), kg.AddVertex(y)
+	kg.graph.AddWeightedEdge(vx, vy, weight)
+}
+
+// RemoveEdge removes one edge from x to y, as described by Graph.RemoveEdge.
+func (kg *KeyedGraph[K]) RemoveEdge(x K, y K) error {
+	vx, ok := kg.index[x]
+	if !ok {
+		return ErrUnknownVertex
+	}
+	vy, ok := kg.index[y]
+	if !ok {
+		return ErrUnknownVertex
+	}
+	return kg.graph.RemoveEdge(vx, vy)
+}
+
+// RemoveVertex removes key and every edge touching it. Keys added after it are
+// renumbered, as described by Graph.RemoveVertex.
+func (kg *KeyedGraph[K]) RemoveVertex(key K) error {
+	v, ok := kg.index[key]
+	if !ok {
+		return ErrUnknownVertex
+	}
+	if err := kg.graph.RemoveVertex(v); err != nil {
+		return err
+	}
+	delete(kg.index, key)
+	kg.keys = append(kg.keys[:v], kg.keys[v+1:]...)
+	for u := v; u < len(kg.keys); u++ {
+		kg.index[kg.keys[u]] = u
+	}
+	return nil
+}
+
+// Translate a slice of vertex numbers to keys.
+func (kg *KeyedGraph[K]) keysOf(vertices []int) []K {
+	keys := make([]K, len(vertices))
+	for i, v := range vertices {
+		keys[i] = kg.keys[v]
+	}
+	return keys
+}
+
+// BreadthFirstTraversal is Graph.BreadthFirstTraversal with callbacks that
+// receive keys. data is indexed by vertex number.
+func (kg *KeyedGraph[K]) BreadthFirstTraversal(
+	start K,
+	pve func(K, *TraversalData) Control,
+	pvl func(K, *TraversalData) Control,
+	pe func(K, K, *TraversalData) Control,
+	data *TraversalData,
+) (*TraversalData, error) {
+	v, ok := kg.index[start]
+	if !ok {
+		return data, ErrUnknownVertex
+	}
+	kpve, kpvl, kpe := kg.callbacks(pve, pvl, pe)
+	return kg.graph.BreadthFirstTraversal(v, kpve, kpvl, kpe, data), nil
+}
+
+// DepthFirstTraversal is Graph.DepthFirstTraversal with callbacks that receive
+// keys. data is indexed by vertex number.
+func (kg *KeyedGraph[K]) DepthFirstTraversal(
+	start K,
+	pve func(K, *TraversalData) Control,
+	pvl func(K, *TraversalData) Control,
+	pe func(K, K, *TraversalData) Control,
+	data *TraversalData,
+) (*TraversalData, error) {
+	v, ok := kg.index[start]
+	if !ok {
+		return data, ErrUnknownVertex
+	}
+	kpve, kpvl, kpe := kg.callbacks(pve, pvl, pe)
+	return kg.graph.DepthFirstTraversal(v, kpve, kpvl, kpe, data), nil
+}
+
+// Wrap keyed callbacks so they can be passed to Graph traversals.
+func (kg *KeyedGraph[K]) callbacks(
+	pve func(K, *TraversalData) Control,
+	pvl func(K, *TraversalData) Control,
+	pe func(K, K, *TraversalData) Control,
+) (processVertex, processVertex, processEdge) {
+	kpve := func(v int, data *TraversalData) Control {
+		return pve(kg.keys[v], data)
+	}
+	kpvl := func(v int, data *TraversalData) Control {
+		return pvl(kg.keys[v], data)
+	}
+	kpe := func(x int, y int, data *TraversalData) Control {
+		return pe(kg.keys[x], kg.keys[y], data)
+	}
+	return kpve, kpvl, kpe
+}
+
+// ConnectedComponentLabels returns the number of connected components, which
+// component each key belongs to, and the number of keys in each component.
+func (kg *KeyedGraph[K]) ConnectedComponentLabels() (int, map[K]int, []int) {
+	count, component, sizes := kg.graph.ConnectedComponentLabels()
+	labels := make(map[K]int, len(kg.index))
+	for key, v := range kg.index {
+		labels[key] = component[v]
+	}
+	return count, labels, sizes
+}
+
+// ArticulationVertices returns a map of all cut-nodes, as described by
+// Graph.ArticulationVertices.
+func (kg *KeyedGraph[K]) ArticulationVertices(start K) (map[K]CutNodeClass, error) {
+	v, ok := kg.index[start]
+	if !ok {
+		return nil, ErrUnknownVertex
+	}
+	vertexCutNodes, err := kg.graph.ArticulationVertices(v)
+	if err != nil {
+		return nil, err
+	}
+	cutNodes := make(map[K]CutNodeClass)
+	for u, label := range vertexCutNodes {
+		cutNodes[kg.keys[u]] = label
+	}
+	return cutNodes, nil
+}
+
+// TopologicalSort returns keys in the order given by Graph.TopologicalSort.
+// Errors are the same as Graph.TopologicalSort; the vertices of a *CycleError
+// can be translated with Key.
+func (kg *KeyedGraph[K]) TopologicalSort() ([]K, error) {
+	sorted, err := kg.graph.TopologicalSort()
+	if err != nil {
+		return nil, err
+	}
+	return kg.keysOf(sorted), nil
+}
+
+// KahnTopologicalSort returns keys in the order given by
+// Graph.KahnTopologicalSort, breaking ties with less. A nil less takes keys in
+// the order they were added. The vertices of a *CyclicSubgraphError can be
+// translated with Key.
+func (kg *KeyedGraph[K]) KahnTopologicalSort(less func(a K, b K) bool) ([]K, error) {
+	var vertexLess func(a int, b int) bool
+	if less != nil {
+		vertexLess = func(a int, b int) bool {
+			return less(kg.keys[a], kg.keys[b])
+		}
+	}
+	sorted, err := kg.graph.KahnTopologicalSort(vertexLess)
+	if err != nil {
+		return nil, err
+	}
+	return kg.keysOf(sorted), nil
+}
+
+// StronglyConnectedComponents returns the number of strongly connected
+// components and which component each key belongs to.
+func (kg *KeyedGraph[K]) StronglyConnectedComponents() (int, map[K]int, error) {
+	count, scc, err := kg.graph.StronglyConnectedComponents()
+	if err != nil {
+		return 0, nil, err
+	}
+	labels := make(map[K]int, len(kg.index))
+	for key, v := range kg.index {
+		labels[key] = scc[v]
+	}
+	return count, labels, nil
+}
+
+// Dijkstra returns the shortest distance from start to every reachable key,
+// along with each key's parent in the shortest path tree. start and
+// unreachable keys have no parent entry.
+func (kg *KeyedGraph[K]) Dijkstra(start K) (map[K]float64, map[K]K, error) {
+	v, ok := kg.index[start]
+	if !ok {
+		return nil, nil, ErrUnknownVertex
+	}
+	distance, parent, err := kg.graph.Dijkstra(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDistance := make(map[K]float64)
+	keyParent := make(map[K]K)
+	for u := 1; u < len(distance); u++ {
+		if math.IsInf(distance[u], 1) {
+			continue
+		}
+		keyDistance[kg.keys[u]] = distance[u]
+		if parent[u] != 0 {
+			keyParent[kg.keys[u]] = kg.keys[parent[u]]
+		}
+	}
+	return keyDistance, keyParent, nil
+}
@@ -0,0 +1,208 @@
+package graph
+
+import "testing"
+
+func TestKeyedInit_sparseIDs(t *testing.T) {
+	// Would index out of range as a plain Graph.
+	edgeList := []int64{1, 100, 100, 7000000000}
+
+	graph := &KeyedGraph[int64]{}
+	graph.Init(false, edgeList)
+
+	if graph.Graph().nVertices != 3 {
+		t.Errorf("nVertices should be 3, got %v", graph.Graph().nVertices)
+	}
+	v, ok := graph.Vertex(7000000000)
+	if !ok || v != 3 {
+		t.Errorf("graph.Vertex(7000000000) should be 3, true, got %v, %v", v, ok)
+	}
+	if graph.Key(2) != 100 {
+		t.Errorf("graph.Key(2) should be 100, got %v", graph.Key(2))
+	}
+}
+
+func TestKeyedBreadthFirstTraversal(t *testing.T) {
+	edgeList := []string{
+		"a", "b",
+		"a", "c",
+		"c", "d",
+	}
+
+	graph := &KeyedGraph[string]{}
+	graph.Init(false, edgeList)
+
+	var order []string
+	processVertexEarly := func(v string, data *TraversalData) Control {
+		order = append(order, v)
+		return CONTINUE
+	}
+	processVertexLate := func(v string, data *TraversalData) Control { return CONTINUE }
+	processEdge := func(x string, y string, data *TraversalData) Control { return CONTINUE }
+
+	data := &TraversalData{}
+	data.Init(graph.Graph())
+	_, err := graph.BreadthFirstTraversal("a", processVertexEarly, processVertexLate, processEdge, data)
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	expected := []string{"a", "c", "b", "d"}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("order should be %v, got %v", expected, order)
+			break
+		}
+	}
+
+	_, err = graph.BreadthFirstTraversal("z", processVertexEarly, processVertexLate, processEdge, data)
+	if err != ErrUnknownVertex {
+		t.Errorf("err should be ErrUnknownVertex, got %v", err)
+	}
+}
+
+func TestKeyedArticulationVertices(t *testing.T) {
+	edgeList := []string{
+		"a", "b",
+		"b", "c",
+	}
+
+	graph := &KeyedGraph[string]{}
+	graph.Init(false, edgeList)
+
+	cutNodes, err := graph.ArticulationVertices("a")
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if len(cutNodes) != 1 {
+		t.Errorf("len(cutNodes) should be 1, got %v", len(cutNodes))
+	}
+	if cutNodes["b"] != BRIDGE {
+		t.Errorf("cutNodes[b] should be bridge, got %v", cutNodes["b"])
+	}
+}
+
+func TestKeyedTopologicalSort(t *testing.T) {
+	edgeList := []string{
+		"fetch", "build",
+		"build", "test",
+		"build", "lint",
+	}
+
+	graph := &KeyedGraph[string]{}
+	graph.Init(true, edgeList)
+
+	sorted, err := graph.TopologicalSort()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	// Reverse topological order, as with Graph.TopologicalSort.
+	if sorted[len(sorted)-1] != "fetch" {
+		t.Errorf("last should be fetch, got %v", sorted)
+	}
+	if sorted[len(sorted)-2] != "build" {
+		t.Errorf("second last should be build, got %v", sorted)
+	}
+}
+
+func TestKeyedKahnTopologicalSort(t *testing.T) {
+	edgeList := []string{
+		"fetch", "build",
+		"build", "test",
+		"build", "lint",
+	}
+
+	graph := &KeyedGraph[string]{}
+	graph.Init(true, edgeList)
+
+	sorted, err := graph.KahnTopologicalSort(func(a string, b string) bool { return a < b })
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	expected := []string{"fetch", "build", "lint", "test"}
+	for i := range expected {
+		if sorted[i] != expected[i] {
+			t.Errorf("sorted should be %v, got %v", expected, sorted)
+			break
+		}
+	}
+}
+
+func TestKeyedStronglyConnectedComponents(t *testing.T) {
+	edgeList := []string{
+		"a", "b",
+		"b", "c",
+		"c", "b",
+	}
+
+	graph := &KeyedGraph[string]{}
+	graph.Init(true, edgeList)
+
+	count, labels, err := graph.StronglyConnectedComponents()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if count != 2 {
+		t.Errorf("count should be 2, got %v", count)
+	}
+	if labels["b"] != labels["c"] {
+		t.Errorf("b and c should share a component, got %v", labels)
+	}
+	if labels["a"] == labels["b"] {
+		t.Errorf("a and b should not share a component, got %v", labels)
+	}
+}
+
+func TestKeyedDijkstra(t *testing.T) {
+	edgeList := []string{
+		"sfo", "den",
+		"den", "jfk",
+		"sfo", "jfk",
+	}
+	weights := []float64{2, 3, 6}
+
+	graph := &KeyedGraph[string]{}
+	graph.InitWeighted(true, edgeList, weights)
+
+	distance, parent, err := graph.Dijkstra("sfo")
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if distance["jfk"] != 5 {
+		t.Errorf("distance[jfk] should be 5, got %v", distance["jfk"])
+	}
+	if parent["jfk"] != "den" {
+		t.Errorf("parent[jfk] should be den, got %v", parent["jfk"])
+	}
+	if _, ok := parent["sfo"]; ok {
+		t.Errorf("sfo should have no parent, got %v", parent["sfo"])
+	}
+}
+
+func TestKeyedMutation(t *testing.T) {
+	graph := &KeyedGraph[string]{}
+	graph.AddEdge("a", "b")
+	graph.AddEdge("b", "c")
+
+	if err := graph.RemoveVertex("a"); err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	v, ok := graph.Vertex("c")
+	if !ok || v != 2 {
+		t.Errorf("graph.Vertex(c) should be 2, true, got %v, %v", v, ok)
+	}
+	if graph.Key(1) != "b" {
+		t.Errorf("graph.Key(1) should be b, got %v", graph.Key(1))
+	}
+	if _, ok := graph.Vertex("a"); ok {
+		t.Errorf("a should be removed")
+	}
+	if !graph.Graph().HasEdge(1, 2) {
+		t.Errorf("edge b, c should remain")
+	}
+}
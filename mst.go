@@ -0,0 +1,91 @@
+package graph
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// PrimMST returns the edges of a minimum spanning tree of the component
+// containing start, in the order they were added, along with the total weight.
+// Each edge runs from a vertex already in the tree to the vertex it adds.
+func (g *Graph) PrimMST(start int) ([]Edge, float64, error) {
+	if g.directed {
+		return nil, 0, ErrDirected
+	}
+	if err := g.checkVertex(start); err != nil {
+		return nil, 0, err
+	}
+
+	// The cheapest known edge weight connecting each vertex to the tree, and the
+	// tree vertex at the other end of it.
+	cheapest := make([]float64, adjustSize(g.nVertices))
+	parent := make([]int, adjustSize(g.nVertices))
+	inTree := make([]bool, adjustSize(g.nVertices))
+	for i := range cheapest {
+		cheapest[i] = math.Inf(1)
+	}
+	cheapest[start] = 0
+
+	var tree []Edge
+	total := 0.0
+	queue := &distanceHeap{{vertex: start, distance: 0}}
+
+	for queue.Len() != 0 {
+		x := heap.Pop(queue).(vertexDistance).vertex
+		if inTree[x] {
+			continue // Stale entry.
+		}
+		inTree[x] = true
+		if x != start {
+			tree = append(tree, Edge{X: parent[x], Y: x, Weight: cheapest[x]})
+			total += cheapest[x]
+		}
+
+		for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+			y := edgePointer.y
+			if !inTree[y] && edgePointer.weight < cheapest[y] {
+				cheapest[y] = edgePointer.weight
+				parent[y] = x
+				heap.Push(queue, vertexDistance{vertex: y, distance: edgePointer.weight})
+			}
+		}
+	}
+
+	return tree, total, nil
+}
+
+// KruskalMST returns the edges of a minimum spanning forest, covering every
+// component, in order of increasing weight along with the total weight.
+func (g *Graph) KruskalMST() ([]Edge, float64, error) {
+	if g.directed {
+		return nil, 0, ErrDirected
+	}
+
+	// Each undirected edge is stored once in each endpoint's list, so only take
+	// it from the smaller endpoint. Self-loops never join two trees.
+	candidates := make([]Edge, 0, g.nEdges)
+	for x := 1; x <= g.nVertices; x++ {
+		for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+			if x < edgePointer.y {
+				candidates = append(candidates, Edge{X: x, Y: edgePointer.y, Weight: edgePointer.weight})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Weight < candidates[j].Weight
+	})
+
+	sets := NewUnionFind(g.nVertices)
+	var forest []Edge
+	total := 0.0
+
+	for _, e := range candidates {
+		if sets.Union(e.X, e.Y) {
+			forest = append(forest, e)
+			total += e.Weight
+		}
+	}
+
+	return forest, total, nil
+}
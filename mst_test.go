@@ -0,0 +1,68 @@
+package graph
+
+import "testing"
+
+// Square 1-2-3-4 with diagonal 1-3 and a separate edge 5-6.
+func mstGraph() *Graph {
+	edgeList := []int{
+		1, 2,
+		2, 3,
+		3, 4,
+		4, 1,
+		1, 3,
+		5, 6,
+	}
+	weights := []float64{1, 2, 5, 4, 3, 7}
+
+	graph := &Graph{}
+	graph.InitWeighted(false, edgeList, weights)
+	return graph
+}
+
+func TestPrimMST(t *testing.T) {
+	graph := mstGraph()
+
+	tree, total, err := graph.PrimMST(1)
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	// Only the component containing 1 is spanned.
+	if len(tree) != 3 {
+		t.Errorf("len(tree) should be 3, got %v", len(tree))
+	}
+	if total != 7 {
+		t.Errorf("total should be 7, got %v", total)
+	}
+	if tree[0] != (Edge{X: 1, Y: 2, Weight: 1}) {
+		t.Errorf("tree[0] should be {1 2 1}, got %v", tree[0])
+	}
+}
+
+func TestKruskalMST(t *testing.T) {
+	graph := mstGraph()
+
+	forest, total, err := graph.KruskalMST()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if len(forest) != 4 {
+		t.Errorf("len(forest) should be 4, got %v", len(forest))
+	}
+	if total != 14 {
+		t.Errorf("total should be 14, got %v", total)
+	}
+	if forest[2] != (Edge{X: 1, Y: 4, Weight: 4}) {
+		t.Errorf("forest[2] should be {1 4 4}, got %v", forest[2])
+	}
+}
+
+func TestKruskalMST_directed(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(true, []int{1, 2})
+
+	if _, _, err := graph.KruskalMST(); err != ErrDirected {
+		t.Errorf("err should be ErrDirected, got %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+package graph
+
+import "fmt"
+
+// AddVertex adds an isolated vertex to the graph and returns it. Vertices are
+// numbered consecutively, so the new vertex is always the highest.
+func (g *Graph) AddVertex() int {
+	if g.edges == nil {
+		g.edges = make([]*edge, adjustSize(0))
+	}
+	g.edges = append(g.edges, nil)
+	g.nVertices++
+	return g.nVertices
+}
+
+// AddEdge adds an edge from x to y with a weight of 1. For undirected graphs
+// the edge is also added from y to x. An error wrapping ErrUnknownVertex is
+// returned if either vertex is not in the graph.
+func (g *Graph) AddEdge(x int, y int) error {
+	return g.AddWeightedEdge(x, y, 1)
+}
+
+// AddWeightedEdge is AddEdge with the given weight.
+func (g *Graph) AddWeightedEdge(x int, y int, weight float64) error {
+	if err := g.checkVertex(x); err != nil {
+		return err
+	}
+	if err := g.checkVertex(y); err != nil {
+		return err
+	}
+	g.insertEdge(g.directed, x, y, weight)
+	return nil
+}
+
+// Unlink up to limit entries for y from x's edge list, returning how many were
+// removed. A limit of 0 removes them all.
+func (g *Graph) unlinkEdge(x int, y int, limit int) int {
+	removed := 0
+	for link := &g.edges[x]; *link != nil; {
+		if (*link).y == y && (limit == 0 || removed < limit) {
+			*link = (*link).next
+			removed++
+		} else {
+			link = &(*link).next
+		}
+	}
+	return removed
+}
+
+// RemoveEdge removes one edge from x to y. For undirected graphs its mirrored
+// entry from y to x is removed too. An error wrapping ErrUnknownEdge is
+// returned if there is no such edge.
+func (g *Graph) RemoveEdge(x int, y int) error {
+	if err := g.checkVertex(x); err != nil {
+		return err
+	}
+	if err := g.checkVertex(y); err != nil {
+		return err
+	}
+	if g.unlinkEdge(x, y, 1) == 0 {
+		return fmt.Errorf("%w: %d, %d", ErrUnknownEdge, x, y)
+	}
+	if !g.directed {
+		g.unlinkEdge(y, x, 1)
+	}
+	g.nEdges--
+	return nil
+}
+
+// RemoveVertex removes v and every edge touching it. To keep vertices numbered
+// 1 to n, every vertex above v is renumbered one lower.
+func (g *Graph) RemoveVertex(v int) error {
+	if err := g.checkVertex(v); err != nil {
+		return err
+	}
+
+	removed := 0
+	if g.directed {
+		for edgePointer := g.edges[v]; edgePointer != nil; edgePointer = edgePointer.next {
+			removed++
+		}
+	} else {
+		// A self-loop is stored twice in v's own list.
+		removed += g.unlinkEdge(v, v, 0) / 2
+	}
+	// Outgoing edges of v were either just counted, or are mirrored by the
+	// entries removed here.
+	for x := 1; x <= g.nVertices; x++ {
+		if x != v {
+			removed += g.unlinkEdge(x, v, 0)
+		}
+	}
+	g.nEdges -= removed
+
+	g.edges = append(g.edges[:v], g.edges[v+1:]...)
+	g.nVertices--
+	for x := 1; x <= g.nVertices; x++ {
+		for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+			if edgePointer.y > v {
+				edgePointer.y--
+			}
+		}
+	}
+
+	return nil
+}
+
+// HasEdge checks if there is an edge from x to y.
+func (g *Graph) HasEdge(x int, y int) bool {
+	if g.checkVertex(x) != nil {
+		return false
+	}
+	for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+		if edgePointer.y == y {
+			return true
+		}
+	}
+	return false
+}
+
+// Degree returns the number of edges leaving v, which for undirected graphs is
+// every edge touching it. A self-loop counts twice in an undirected graph. 0 is
+// returned if v is not in the graph.
+func (g *Graph) Degree(v int) int {
+	if g.checkVertex(v) != nil {
+		return 0
+	}
+	degree := 0
+	for edgePointer := g.edges[v]; edgePointer != nil; edgePointer = edgePointer.next {
+		degree++
+	}
+	return degree
+}
+
+// Neighbors returns the vertices that v has an edge to, in adjacency list
+// order. A vertex appears once per parallel edge. nil is returned if v is not
+// in the graph.
+func (g *Graph) Neighbors(v int) []int {
+	if g.checkVertex(v) != nil {
+		return nil
+	}
+	var neighbors []int
+	for edgePointer := g.edges[v]; edgePointer != nil; edgePointer = edgePointer.next {
+		neighbors = append(neighbors, edgePointer.y)
+	}
+	return neighbors
+}
@@ -0,0 +1,139 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAddVertex(t *testing.T) {
+	graph := &Graph{}
+
+	if v := graph.AddVertex(); v != 1 {
+		t.Errorf("v should be 1, got %v", v)
+	}
+	if v := graph.AddVertex(); v != 2 {
+		t.Errorf("v should be 2, got %v", v)
+	}
+	if err := graph.AddEdge(1, 2); err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if graph.nVertices != 2 {
+		t.Errorf("graph.nVertices should be 2, got %v", graph.nVertices)
+	}
+	if graph.nEdges != 1 {
+		t.Errorf("graph.nEdges should be 1, got %v", graph.nEdges)
+	}
+	if !graph.HasEdge(2, 1) {
+		t.Errorf("graph.HasEdge(2, 1) should be true, got false")
+	}
+}
+
+func TestAddEdge_unknownVertex(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(true, []int{1, 2})
+
+	if err := graph.AddEdge(1, 3); !errors.Is(err, ErrUnknownVertex) {
+		t.Errorf("err should wrap ErrUnknownVertex, got %v", err)
+	}
+}
+
+func TestRemoveEdge(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		1, 3,
+		2, 3,
+	}
+
+	graph := &Graph{}
+	graph.Init(false, edgeList)
+
+	if err := graph.RemoveEdge(2, 1); err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if graph.nEdges != 2 {
+		t.Errorf("graph.nEdges should be 2, got %v", graph.nEdges)
+	}
+	if graph.HasEdge(1, 2) || graph.HasEdge(2, 1) {
+		t.Errorf("edge 1, 2 should be removed in both directions")
+	}
+	if err := graph.RemoveEdge(2, 1); !errors.Is(err, ErrUnknownEdge) {
+		t.Errorf("err should wrap ErrUnknownEdge, got %v", err)
+	}
+}
+
+func TestRemoveVertex(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		2, 3,
+		3, 4,
+		4, 2,
+		2, 2,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	if err := graph.RemoveVertex(2); err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if graph.nVertices != 3 {
+		t.Errorf("graph.nVertices should be 3, got %v", graph.nVertices)
+	}
+	if graph.nEdges != 1 {
+		t.Errorf("graph.nEdges should be 1, got %v", graph.nEdges)
+	}
+	// Edge 3, 4 is renumbered to 2, 3.
+	if !graph.HasEdge(2, 3) {
+		t.Errorf("graph.HasEdge(2, 3) should be true, got false")
+	}
+}
+
+func TestRemoveVertex_undirected(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		2, 3,
+		3, 1,
+		3, 3,
+	}
+
+	graph := &Graph{}
+	graph.Init(false, edgeList)
+
+	if err := graph.RemoveVertex(3); err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if graph.nEdges != 1 {
+		t.Errorf("graph.nEdges should be 1, got %v", graph.nEdges)
+	}
+	if graph.Degree(1) != 1 {
+		t.Errorf("graph.Degree(1) should be 1, got %v", graph.Degree(1))
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		1, 3,
+		3, 1,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	neighbors := graph.Neighbors(1)
+
+	// Edges are placed at the head of the list.
+	if len(neighbors) != 2 || neighbors[0] != 3 || neighbors[1] != 2 {
+		t.Errorf("neighbors should be [3 2], got %v", neighbors)
+	}
+	if graph.Degree(1) != 2 {
+		t.Errorf("graph.Degree(1) should be 2, got %v", graph.Degree(1))
+	}
+	if graph.Neighbors(4) != nil {
+		t.Errorf("graph.Neighbors(4) should be nil, got %v", graph.Neighbors(4))
+	}
+}
@@ -0,0 +1,535 @@
+package graph
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+)
+
+// A vertex and its tentative distance from the source. Entries go stale when a
+// shorter distance is found later; they are skipped when popped instead of
+// being updated in place.
+type vertexDistance struct {
+	vertex   int
+	distance float64
+}
+
+// Min-heap of vertexDistance ordered by distance. Implements heap.Interface.
+type distanceHeap []vertexDistance
+
+func (h distanceHeap) Len() int           { return len(h) }
+func (h distanceHeap) Less(i, j int) bool { return h[i].distance < h[j].distance }
+func (h distanceHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *distanceHeap) Push(x interface{}) {
+	*h = append(*h, x.(vertexDistance))
+}
+
+func (h *distanceHeap) Pop() interface{} {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+// Dijkstra returns the shortest distance from start to every vertex, along with
+// the parent of each vertex in the shortest path tree. Both are indexed by
+// vertex. Unreachable vertices have a distance of +Inf and, like start, a parent
+// of 0. ErrNegativeWeight is returned if a negative edge weight is found.
+func (g *Graph) Dijkstra(start int) ([]float64, []int, error) {
+	if err := g.checkVertex(start); err != nil {
+		return nil, nil, err
+	}
+
+	distance := make([]float64, adjustSize(g.nVertices))
+	parent := make([]int, adjustSize(g.nVertices))
+	// Whether the shortest distance to a vertex is final.
+	done := make([]bool, adjustSize(g.nVertices))
+	for i := range distance {
+		distance[i] = math.Inf(1)
+	}
+	distance[start] = 0
+
+	queue := &distanceHeap{{vertex: start, distance: 0}}
+
+	for queue.Len() != 0 {
+		x := heap.Pop(queue).(vertexDistance).vertex
+		if done[x] {
+			continue // Stale entry.
+		}
+		done[x] = true
+
+		for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+			if edgePointer.weight < 0 {
+				return nil, nil, ErrNegativeWeight
+			}
+			y := edgePointer.y
+			if d := distance[x] + edgePointer.weight; d < distance[y] {
+				distance[y] = d
+				parent[y] = x
+				heap.Push(queue, vertexDistance{vertex: y, distance: d})
+			}
+		}
+	}
+
+	return distance, parent, nil
+}
+
+// BellmanFord returns the shortest distance from start to every vertex, along
+// with the parent of each vertex in the shortest path tree, in the same form as
+// Dijkstra. Negative edge weights are allowed. If a negative cycle is reachable
+// from start, a *NegativeCycleError holding the cycle is returned.
+func (g *Graph) BellmanFord(start int) ([]float64, []int, error) {
+	if err := g.checkVertex(start); err != nil {
+		return nil, nil, err
+	}
+
+	distance := make([]float64, adjustSize(g.nVertices))
+	parent := make([]int, adjustSize(g.nVertices))
+	for i := range distance {
+		distance[i] = math.Inf(1)
+	}
+	distance[start] = 0
+
+	// Relax every edge, returning the last vertex whose distance changed or 0 if
+	// nothing changed.
+	relax := func() int {
+		changed := 0
+		for x := 1; x <= g.nVertices; x++ {
+			if math.IsInf(distance[x], 1) {
+				continue
+			}
+			for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+				y := edgePointer.y
+				if d := distance[x] + edgePointer.weight; d < distance[y] {
+					distance[y] = d
+					parent[y] = x
+					changed = y
+				}
+			}
+		}
+		return changed
+	}
+
+	// Shortest paths have at most nVertices-1 edges, so distances settle after
+	// that many rounds unless there is a negative cycle.
+	for i := 1; i < g.nVertices; i++ {
+		if relax() == 0 {
+			return distance, parent, nil
+		}
+	}
+
+	changed := relax()
+	if changed == 0 {
+		return distance, parent, nil
+	}
+
+	// changed is either on a negative cycle or downstream of one. Walking back
+	// nVertices parents is guaranteed to land on the cycle itself.
+	v := changed
+	for i := 0; i < g.nVertices; i++ {
+		v = parent[v]
+	}
+	cycle := []int{v}
+	for u := parent[v]; u != v; u = parent[u] {
+		cycle = append(cycle, u)
+	}
+	// The parent walk produced the cycle backwards.
+	for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+		cycle[i], cycle[j] = cycle[j], cycle[i]
+	}
+
+	return nil, nil, &NegativeCycleError{Cycle: cycle}
+}
+
+// FloydWarshall returns the shortest distance between every pair of vertices,
+// along with a next-hop matrix for reconstructing paths with NextHopPath. Both
+// are indexed [from][to] by vertex. Unreachable pairs have a distance of +Inf
+// and a next hop of 0. Runs in O(V^3), which suits dense graphs. If the graph
+// has a negative cycle, a *NegativeCycleError holding the cycle is returned.
+func (g *Graph) FloydWarshall() ([][]float64, [][]int, error) {
+	size := adjustSize(g.nVertices)
+	distance := make([][]float64, size)
+	next := make([][]int, size)
+	for i := 1; i < size; i++ {
+		distance[i] = make([]float64, size)
+		next[i] = make([]int, size)
+		for j := 1; j < size; j++ {
+			distance[i][j] = math.Inf(1)
+		}
+		distance[i][i] = 0
+	}
+
+	for x := 1; x <= g.nVertices; x++ {
+		for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+			y := edgePointer.y
+			// Keep the cheapest of any parallel edges.
+			if edgePointer.weight < distance[x][y] {
+				distance[x][y] = edgePointer.weight
+				next[x][y] = y
+			}
+		}
+	}
+
+	for k := 1; k <= g.nVertices; k++ {
+		for i := 1; i <= g.nVertices; i++ {
+			if math.IsInf(distance[i][k], 1) {
+				continue
+			}
+			for j := 1; j <= g.nVertices; j++ {
+				if d := distance[i][k] + distance[k][j]; d < distance[i][j] {
+					distance[i][j] = d
+					next[i][j] = next[i][k]
+				}
+			}
+		}
+	}
+
+	// A vertex that can reach itself at negative cost lies on a negative cycle.
+	// Bellman-Ford from that vertex recovers the cycle itself.
+	for i := 1; i <= g.nVertices; i++ {
+		if distance[i][i] < 0 {
+			_, _, err := g.BellmanFord(i)
+			return nil, nil, err
+		}
+	}
+
+	return distance, next, nil
+}
+
+// Johnson returns the same all-pairs distance and next-hop matrices as
+// FloydWarshall. Edges are reweighted to be non-negative using Bellman-Ford from
+// a virtual source, then Dijkstra is run back from every vertex on the
+// transposed graph. Runs in O(VE log V), which suits sparse graphs. If the
+// graph has a negative cycle, a *NegativeCycleError holding the cycle is
+// returned.
+func (g *Graph) Johnson() ([][]float64, [][]int, error) {
+	// Add a virtual source with a zero weight edge to every vertex. Its
+	// distances give each vertex a potential h.
+	augmented := g.clone()
+	augmented.directed = true
+	source := adjustSize(g.nVertices)
+	augmented.nVertices = source
+	// A graph that was never initialized has no edge lists at all.
+	edges := make([]*edge, adjustSize(source))
+	copy(edges, augmented.edges)
+	augmented.edges = edges
+	for v := 1; v <= g.nVertices; v++ {
+		augmented.insertEdge(true, source, v, 0)
+	}
+	h, _, err := augmented.BellmanFord(source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// w(x, y) + h(x) - h(y) is never negative. Clamp to absorb rounding error.
+	reweighted := g.clone()
+	for x := 1; x <= g.nVertices; x++ {
+		for edgePointer := reweighted.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+			edgePointer.weight = math.Max(0, edgePointer.weight+h[x]-h[edgePointer.y])
+		}
+	}
+
+	size := adjustSize(g.nVertices)
+	distance := make([][]float64, size)
+	next := make([][]int, size)
+	for u := 1; u < size; u++ {
+		distance[u] = make([]float64, size)
+		next[u] = make([]int, size)
+	}
+
+	// Searching back from each target on the transposed graph gives one
+	// shortest path tree per target. Every next hop toward v comes from the
+	// same tree, so following them cannot loop, even when paths tie.
+	transposed := reweighted.Transpose()
+	for v := 1; v < size; v++ {
+		d, parent, err := transposed.Dijkstra(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		for u := 1; u < size; u++ {
+			distance[u][v] = d[u]
+			if !math.IsInf(d[u], 1) {
+				distance[u][v] += h[v] - h[u]
+			}
+			next[u][v] = parent[u]
+		}
+	}
+
+	return distance, next, nil
+}
+
+// NextHopPath reconstructs the path from u to v using a next-hop matrix
+// returned by FloydWarshall or Johnson. It returns nil if v is unreachable.
+func NextHopPath(next [][]int, u int, v int) []int {
+	if u == v {
+		return []int{u}
+	}
+	if next[u][v] == 0 {
+		return nil
+	}
+	path := []int{u}
+	for u != v {
+		u = next[u][v]
+		path = append(path, u)
+	}
+	return path
+}
+
+// AStar returns the cheapest path from start to goal and its cost, using
+// heuristic to estimate the remaining cost from a vertex to goal. The path is
+// optimal as long as the heuristic never overestimates, which is left to the
+// caller. If goal is unreachable the path is nil and the cost is +Inf.
+// ErrNegativeWeight is returned if a negative edge weight is found.
+func (g *Graph) AStar(start int, goal int, heuristic func(v int) float64) ([]int, float64, error) {
+	if err := g.checkVertex(start); err != nil {
+		return nil, 0, err
+	}
+	if err := g.checkVertex(goal); err != nil {
+		return nil, 0, err
+	}
+
+	// distance holds the cheapest known cost from start, not the estimate.
+	distance := make([]float64, adjustSize(g.nVertices))
+	parent := make([]int, adjustSize(g.nVertices))
+	for i := range distance {
+		distance[i] = math.Inf(1)
+	}
+	distance[start] = 0
+
+	// Ordered by estimated total cost. A vertex may be reopened if a cheaper
+	// path to it is found after it was expanded, which only happens with an
+	// inconsistent heuristic.
+	queue := &distanceHeap{{vertex: start, distance: heuristic(start)}}
+
+	for queue.Len() != 0 {
+		item := heap.Pop(queue).(vertexDistance)
+		x := item.vertex
+		if item.distance > distance[x]+heuristic(x) {
+			continue // Stale entry.
+		}
+		if x == goal {
+			break
+		}
+
+		for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+			if edgePointer.weight < 0 {
+				return nil, 0, ErrNegativeWeight
+			}
+			y := edgePointer.y
+			if d := distance[x] + edgePointer.weight; d < distance[y] {
+				distance[y] = d
+				parent[y] = x
+				heap.Push(queue, vertexDistance{vertex: y, distance: d + heuristic(y)})
+			}
+		}
+	}
+
+	if math.IsInf(distance[goal], 1) {
+		return nil, distance[goal], nil
+	}
+
+	path := []int{goal}
+	for v := goal; v != start; v = parent[v] {
+		path = append(path, parent[v])
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path, distance[goal], nil
+}
+
+// CheckHeuristic verifies that heuristic is consistent for goal: it is 0 at
+// goal and never drops by more than an edge's weight across that edge. A
+// consistent heuristic is also admissible. Intended for tests of heuristics
+// passed to AStar. An error wrapping ErrInconsistentHeuristic names the first
+// offending vertex or edge.
+func (g *Graph) CheckHeuristic(goal int, heuristic func(v int) float64) error {
+	if err := g.checkVertex(goal); err != nil {
+		return err
+	}
+	if h := heuristic(goal); h != 0 {
+		return fmt.Errorf("%w: h(%d) = %v at goal", ErrInconsistentHeuristic, goal, h)
+	}
+	for x := 1; x <= g.nVertices; x++ {
+		for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+			y := edgePointer.y
+			if heuristic(x) > edgePointer.weight+heuristic(y) {
+				return fmt.Errorf("%w: h(%d) > w(%d, %d) + h(%d)", ErrInconsistentHeuristic, x, x, y, y)
+			}
+		}
+	}
+	return nil
+}
+
+// ShortestPath returns a path from s to t with the fewest edges, and its
+// number of edges. The breadth-first search stops as soon as t is reached. If
+// t is unreachable the path is nil and the count is -1.
+func (g *Graph) ShortestPath(s int, t int) ([]int, int, error) {
+	if err := g.checkVertex(s); err != nil {
+		return nil, -1, err
+	}
+	if err := g.checkVertex(t); err != nil {
+		return nil, -1, err
+	}
+	if s == t {
+		return []int{s}, 0, nil
+	}
+
+	data := &TraversalData{}
+	data.Init(g)
+
+	// The vertex t was reached from.
+	last := 0
+
+	pve := func(v int, data *TraversalData) Control { return CONTINUE }
+	pvl := func(v int, data *TraversalData) Control { return CONTINUE }
+	pe := func(x int, y int, data *TraversalData) Control {
+		if y == t {
+			last = x
+			return STOP
+		}
+		return CONTINUE
+	}
+
+	g.BreadthFirstTraversal(s, pve, pvl, pe, data)
+	if last == 0 {
+		return nil, -1, nil
+	}
+
+	path := append(data.PathTo(last), t)
+	return path, len(path) - 1, nil
+}
+
+// BidirectionalShortestPath returns the same result as ShortestPath, but
+// searches forward from s and backward from t at the same time, which explores
+// far fewer vertices on large graphs. Directed graphs are searched backward
+// along incoming edges. The search stops once the frontiers meet.
+func (g *Graph) BidirectionalShortestPath(s int, t int) ([]int, int, error) {
+	if err := g.checkVertex(s); err != nil {
+		return nil, -1, err
+	}
+	if err := g.checkVertex(t); err != nil {
+		return nil, -1, err
+	}
+	if s == t {
+		return []int{s}, 0, nil
+	}
+
+	reverse := g
+	if g.directed {
+		reverse = g.Transpose()
+	}
+
+	// One direction of the search. distance is -1 for undiscovered vertices.
+	type search struct {
+		graph    *Graph
+		distance []int
+		parent   []int
+		frontier []int
+	}
+	newSearch := func(graph *Graph, start int) *search {
+		half := &search{
+			graph:    graph,
+			distance: make([]int, adjustSize(g.nVertices)),
+			parent:   make([]int, adjustSize(g.nVertices)),
+			frontier: []int{start},
+		}
+		for i := range half.distance {
+			half.distance[i] = -1
+		}
+		half.distance[start] = 0
+		return half
+	}
+	forward, backward := newSearch(g, s), newSearch(reverse, t)
+
+	// The vertex where the shortest path found so far crosses between the
+	// searches, and its length.
+	meet, best := 0, -1
+
+	for meet == 0 && len(forward.frontier) != 0 && len(backward.frontier) != 0 {
+		// Expand one whole level of the smaller frontier. Every meeting point
+		// within the first level that meets is a candidate for the shortest path.
+		this, other := forward, backward
+		if len(backward.frontier) < len(forward.frontier) {
+			this, other = backward, forward
+		}
+		var next []int
+		for _, x := range this.frontier {
+			for edgePointer := this.graph.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+				y := edgePointer.y
+				if this.distance[y] != -1 {
+					continue
+				}
+				this.distance[y] = this.distance[x] + 1
+				this.parent[y] = x
+				next = append(next, y)
+				if other.distance[y] != -1 {
+					if length := this.distance[y] + other.distance[y]; best == -1 || length < best {
+						meet, best = y, length
+					}
+				}
+			}
+		}
+		this.frontier = next
+	}
+
+	if meet == 0 {
+		return nil, -1, nil
+	}
+
+	var path []int
+	for v := meet; v != 0; v = forward.parent[v] {
+		path = append(path, v)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	for v := backward.parent[meet]; v != 0; v = backward.parent[v] {
+		path = append(path, v)
+	}
+
+	return path, best, nil
+}
+
+// BreadthFirstLayers runs a multi-source breadth-first traversal from starts
+// and returns the fewest edges from any start to each vertex, along with the
+// vertices grouped into layers by that distance. layers[0] holds the starts.
+// Unreachable vertices have a distance of -1 and are in no layer.
+func (g *Graph) BreadthFirstLayers(starts []int) ([]int, [][]int, error) {
+	for _, start := range starts {
+		if err := g.checkVertex(start); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	data := &TraversalData{}
+	data.Init(g)
+
+	distance := make([]int, adjustSize(g.nVertices))
+	for i := range distance {
+		distance[i] = -1
+	}
+	var layers [][]int
+
+	// Vertices are processed in order of distance, so each one belongs to the
+	// last layer or starts a new one.
+	pve := func(v int, data *TraversalData) Control {
+		distance[v] = 0
+		if p := data.parent[v]; p != 0 {
+			distance[v] = distance[p] + 1
+		}
+		if distance[v] == len(layers) {
+			layers = append(layers, nil)
+		}
+		layers[distance[v]] = append(layers[distance[v]], v)
+		return CONTINUE
+	}
+	pvl := func(v int, data *TraversalData) Control { return CONTINUE }
+	pe := func(x int, y int, data *TraversalData) Control { return CONTINUE }
+
+	g.MultiSourceBreadthFirstTraversal(starts, pve, pvl, pe, data)
+
+	return distance, layers, nil
+}
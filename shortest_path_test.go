@@ -0,0 +1,551 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDijkstra(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		1, 3,
+		3, 2,
+		2, 4,
+	}
+	weights := []float64{10, 3, 4, 2}
+
+	graph := &Graph{}
+	graph.InitWeighted(true, edgeList, weights)
+
+	distance, parent, err := graph.Dijkstra(1)
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if distance[2] != 7 {
+		t.Errorf("distance[2] should be 7, got %v", distance[2])
+	}
+	if distance[4] != 9 {
+		t.Errorf("distance[4] should be 9, got %v", distance[4])
+	}
+	if parent[1] != 0 {
+		t.Errorf("parent[1] should be 0, got %v", parent[1])
+	}
+	if parent[2] != 3 {
+		t.Errorf("parent[2] should be 3, got %v", parent[2])
+	}
+	if parent[4] != 2 {
+		t.Errorf("parent[4] should be 2, got %v", parent[4])
+	}
+}
+
+func TestDijkstra_unreachable(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		3, 1,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	distance, _, err := graph.Dijkstra(1)
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if distance[2] != 1 {
+		t.Errorf("distance[2] should be 1, got %v", distance[2])
+	}
+	if !math.IsInf(distance[3], 1) {
+		t.Errorf("distance[3] should be +Inf, got %v", distance[3])
+	}
+}
+
+func TestDijkstra_negativeWeight(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		2, 3,
+	}
+	weights := []float64{1, -1}
+
+	graph := &Graph{}
+	graph.InitWeighted(true, edgeList, weights)
+
+	if _, _, err := graph.Dijkstra(1); err != ErrNegativeWeight {
+		t.Errorf("err should be ErrNegativeWeight, got %v", err)
+	}
+}
+
+func TestBellmanFord(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		1, 3,
+		3, 2,
+		2, 4,
+	}
+	weights := []float64{4, 5, -3, 1}
+
+	graph := &Graph{}
+	graph.InitWeighted(true, edgeList, weights)
+
+	distance, parent, err := graph.BellmanFord(1)
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if distance[2] != 2 {
+		t.Errorf("distance[2] should be 2, got %v", distance[2])
+	}
+	if distance[4] != 3 {
+		t.Errorf("distance[4] should be 3, got %v", distance[4])
+	}
+	if parent[2] != 3 {
+		t.Errorf("parent[2] should be 3, got %v", parent[2])
+	}
+}
+
+func TestBellmanFord_negativeCycle(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		2, 3,
+		3, 4,
+		4, 2,
+	}
+	weights := []float64{1, 1, -3, 1}
+
+	graph := &Graph{}
+	graph.InitWeighted(true, edgeList, weights)
+
+	_, _, err := graph.BellmanFord(1)
+	cycleErr, ok := err.(*NegativeCycleError)
+	if !ok {
+		t.Fatalf("err should be *NegativeCycleError, got %v", err)
+	}
+
+	if len(cycleErr.Cycle) != 3 {
+		t.Fatalf("len(cycleErr.Cycle) should be 3, got %v", len(cycleErr.Cycle))
+	}
+	// Every consecutive pair, wrapping around, must be an edge of the cycle.
+	next := map[int]int{2: 3, 3: 4, 4: 2}
+	for i, v := range cycleErr.Cycle {
+		w := cycleErr.Cycle[(i+1)%len(cycleErr.Cycle)]
+		if next[v] != w {
+			t.Errorf("cycle %v should follow edges 2->3->4->2", cycleErr.Cycle)
+			break
+		}
+	}
+}
+
+func TestFloydWarshall(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		1, 3,
+		3, 2,
+		2, 4,
+	}
+	weights := []float64{4, 5, -3, 1}
+
+	graph := &Graph{}
+	graph.InitWeighted(true, edgeList, weights)
+
+	distance, next, err := graph.FloydWarshall()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if distance[1][4] != 3 {
+		t.Errorf("distance[1][4] should be 3, got %v", distance[1][4])
+	}
+	if !math.IsInf(distance[4][1], 1) {
+		t.Errorf("distance[4][1] should be +Inf, got %v", distance[4][1])
+	}
+
+	path := NextHopPath(next, 1, 4)
+	expected := []int{1, 3, 2, 4}
+	if len(path) != len(expected) {
+		t.Fatalf("path should be %v, got %v", expected, path)
+	}
+	for i := range expected {
+		if path[i] != expected[i] {
+			t.Errorf("path should be %v, got %v", expected, path)
+			break
+		}
+	}
+	if NextHopPath(next, 4, 1) != nil {
+		t.Errorf("NextHopPath(next, 4, 1) should be nil, got %v", NextHopPath(next, 4, 1))
+	}
+}
+
+func TestFloydWarshall_negativeCycle(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		2, 1,
+	}
+	weights := []float64{1, -2}
+
+	graph := &Graph{}
+	graph.InitWeighted(true, edgeList, weights)
+
+	if _, _, err := graph.FloydWarshall(); err == nil {
+		t.Errorf("err should be *NegativeCycleError, got nil")
+	}
+}
+
+func TestJohnson(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		1, 3,
+		3, 2,
+		2, 4,
+		4, 1,
+	}
+	weights := []float64{4, 5, -3, 1, 2}
+
+	graph := &Graph{}
+	graph.InitWeighted(true, edgeList, weights)
+
+	expectedDistance, _, err := graph.FloydWarshall()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+	distance, next, err := graph.Johnson()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	for i := 1; i <= 4; i++ {
+		for j := 1; j <= 4; j++ {
+			if distance[i][j] != expectedDistance[i][j] {
+				t.Errorf("distance[%d][%d] should be %v, got %v", i, j, expectedDistance[i][j], distance[i][j])
+			}
+		}
+	}
+
+	path := NextHopPath(next, 4, 2)
+	expected := []int{4, 1, 3, 2}
+	if len(path) != len(expected) {
+		t.Fatalf("path should be %v, got %v", expected, path)
+	}
+	for i := range expected {
+		if path[i] != expected[i] {
+			t.Errorf("path should be %v, got %v", expected, path)
+			break
+		}
+	}
+}
+
+func TestJohnson_negativeCycle(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		2, 3,
+		3, 1,
+	}
+	weights := []float64{1, 1, -3}
+
+	graph := &Graph{}
+	graph.InitWeighted(true, edgeList, weights)
+
+	_, _, err := graph.Johnson()
+	if _, ok := err.(*NegativeCycleError); !ok {
+		t.Errorf("err should be *NegativeCycleError, got %v", err)
+	}
+}
+
+func TestJohnson_zeroWeightTie(t *testing.T) {
+	// The zero weight edge 5-4 makes several paths from 2 to 6 equally short.
+	edgeList := []int{
+		1, 5,
+		2, 5,
+		3, 6,
+		4, 3,
+		5, 4,
+		6, 1,
+		4, 2,
+	}
+	weights := []float64{2, 2, 2, 2, 0, 2, 1}
+
+	graph := &Graph{}
+	graph.InitWeighted(false, edgeList, weights)
+
+	distance, next, err := graph.Johnson()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	// The cheapest edge from x to y.
+	weight := func(x int, y int) float64 {
+		cheapest := math.Inf(1)
+		for edgePointer := graph.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+			if edgePointer.y == y {
+				cheapest = math.Min(cheapest, edgePointer.weight)
+			}
+		}
+		return cheapest
+	}
+
+	for u := 1; u <= 6; u++ {
+		for v := 1; v <= 6; v++ {
+			// Follow the hops with a limit first, since a loop would never let
+			// NextHopPath return.
+			x, steps := u, 0
+			for x != v && x != 0 && steps <= 6 {
+				x = next[x][v]
+				steps++
+			}
+			if x != v {
+				t.Fatalf("next hops from %v should reach %v", u, v)
+			}
+
+			path := NextHopPath(next, u, v)
+			cost := 0.0
+			for i := 1; i < len(path); i++ {
+				cost += weight(path[i-1], path[i])
+			}
+			if cost != distance[u][v] {
+				t.Errorf("NextHopPath(next, %v, %v) should cost %v, got %v", u, v, distance[u][v], cost)
+			}
+		}
+	}
+}
+
+func TestJohnson_uninitialized(t *testing.T) {
+	graph := &Graph{}
+
+	distance, next, err := graph.Johnson()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+	if len(distance) != 1 || len(next) != 1 {
+		t.Errorf("distance and next should only have index 0, got %v and %v", distance, next)
+	}
+}
+
+// A 3x3 grid with unit weights. Vertex v is at row (v-1)/3, column (v-1)%3.
+func gridGraph() *Graph {
+	edgeList := []int{
+		1, 2, 2, 3,
+		4, 5, 5, 6,
+		7, 8, 8, 9,
+		1, 4, 4, 7,
+		2, 5, 5, 8,
+		3, 6, 6, 9,
+	}
+	graph := &Graph{}
+	graph.Init(false, edgeList)
+	return graph
+}
+
+// Manhattan distance to vertex 9.
+func manhattan(v int) float64 {
+	row, column := (v-1)/3, (v-1)%3
+	return float64((2 - row) + (2 - column))
+}
+
+func TestAStar(t *testing.T) {
+	graph := gridGraph()
+
+	path, cost, err := graph.AStar(1, 9, manhattan)
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if cost != 4 {
+		t.Errorf("cost should be 4, got %v", cost)
+	}
+	if len(path) != 5 {
+		t.Fatalf("len(path) should be 5, got %v", len(path))
+	}
+	if path[0] != 1 || path[4] != 9 {
+		t.Errorf("path should run from 1 to 9, got %v", path)
+	}
+}
+
+func TestAStar_unreachable(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		3, 2,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	zero := func(v int) float64 { return 0 }
+	path, cost, err := graph.AStar(1, 3, zero)
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if path != nil {
+		t.Errorf("path should be nil, got %v", path)
+	}
+	if !math.IsInf(cost, 1) {
+		t.Errorf("cost should be +Inf, got %v", cost)
+	}
+}
+
+func TestCheckHeuristic(t *testing.T) {
+	graph := gridGraph()
+
+	if err := graph.CheckHeuristic(9, manhattan); err != nil {
+		t.Errorf("err should be nil, got %v", err)
+	}
+
+	overestimate := func(v int) float64 { return 2 * manhattan(v) }
+	if err := graph.CheckHeuristic(9, overestimate); !errors.Is(err, ErrInconsistentHeuristic) {
+		t.Errorf("err should wrap ErrInconsistentHeuristic, got %v", err)
+	}
+}
+
+func TestDijkstra_unknownVertex(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(true, []int{1, 2})
+
+	if _, _, err := graph.Dijkstra(3); !errors.Is(err, ErrUnknownVertex) {
+		t.Errorf("err should wrap ErrUnknownVertex, got %v", err)
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	graph := gridGraph()
+
+	path, hops, err := graph.ShortestPath(1, 9)
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if hops != 4 {
+		t.Errorf("hops should be 4, got %v", hops)
+	}
+	if len(path) != 5 || path[0] != 1 || path[4] != 9 {
+		t.Errorf("path should run from 1 to 9 in 4 hops, got %v", path)
+	}
+}
+
+func TestShortestPath_unreachable(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		3, 2,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	path, hops, err := graph.ShortestPath(1, 3)
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if path != nil {
+		t.Errorf("path should be nil, got %v", path)
+	}
+	if hops != -1 {
+		t.Errorf("hops should be -1, got %v", hops)
+	}
+}
+
+func TestBidirectionalShortestPath(t *testing.T) {
+	graph := gridGraph()
+
+	path, hops, err := graph.BidirectionalShortestPath(1, 9)
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if hops != 4 {
+		t.Errorf("hops should be 4, got %v", hops)
+	}
+	if len(path) != 5 || path[0] != 1 || path[4] != 9 {
+		t.Fatalf("path should run from 1 to 9 in 4 hops, got %v", path)
+	}
+	for i := 0; i < len(path)-1; i++ {
+		if !graph.HasEdge(path[i], path[i+1]) {
+			t.Errorf("path %v uses missing edge %d, %d", path, path[i], path[i+1])
+		}
+	}
+}
+
+func TestBidirectionalShortestPath_directed(t *testing.T) {
+	// The short way round is against the direction of the edges.
+	edgeList := []int{
+		1, 2,
+		2, 3,
+		3, 4,
+		4, 5,
+		1, 5,
+		5, 6,
+		6, 1,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	path, hops, err := graph.BidirectionalShortestPath(2, 1)
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	expected := []int{2, 3, 4, 5, 6, 1}
+	if hops != 5 {
+		t.Errorf("hops should be 5, got %v", hops)
+	}
+	if len(path) != len(expected) {
+		t.Fatalf("path should be %v, got %v", expected, path)
+	}
+	for i := range expected {
+		if path[i] != expected[i] {
+			t.Errorf("path should be %v, got %v", expected, path)
+			break
+		}
+	}
+
+	if path, hops, _ := graph.BidirectionalShortestPath(1, 1); hops != 0 || len(path) != 1 {
+		t.Errorf("path from 1 to 1 should be [1], got %v", path)
+	}
+}
+
+func TestBreadthFirstLayers(t *testing.T) {
+	graph := gridGraph()
+
+	// Nearest of two opposite corners.
+	distance, layers, err := graph.BreadthFirstLayers([]int{1, 9})
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	expected := []int{0, 0, 1, 2, 1, 2, 1, 2, 1, 0}
+	for v := 1; v <= 9; v++ {
+		if distance[v] != expected[v] {
+			t.Errorf("distance[%v] should be %v, got %v", v, expected[v], distance[v])
+		}
+	}
+
+	if len(layers) != 3 {
+		t.Fatalf("len(layers) should be 3, got %v", len(layers))
+	}
+	if len(layers[0]) != 2 || len(layers[1]) != 4 || len(layers[2]) != 3 {
+		t.Errorf("layers should have sizes 2, 4 and 3, got %v", layers)
+	}
+}
+
+func TestBreadthFirstLayers_unreachable(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		3, 2,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	distance, layers, err := graph.BreadthFirstLayers([]int{1})
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if distance[3] != -1 {
+		t.Errorf("distance[3] should be -1, got %v", distance[3])
+	}
+	if len(layers) != 2 {
+		t.Errorf("len(layers) should be 2, got %v", len(layers))
+	}
+}
@@ -0,0 +1,272 @@
+package graph
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// Heap of vertices ordered by less. Implements heap.Interface.
+type vertexHeap struct {
+	vertices []int
+	less     func(a int, b int) bool
+}
+
+func (h *vertexHeap) Len() int           { return len(h.vertices) }
+func (h *vertexHeap) Less(i, j int) bool { return h.less(h.vertices[i], h.vertices[j]) }
+func (h *vertexHeap) Swap(i, j int)      { h.vertices[i], h.vertices[j] = h.vertices[j], h.vertices[i] }
+
+func (h *vertexHeap) Push(x interface{}) {
+	h.vertices = append(h.vertices, x.(int))
+}
+
+func (h *vertexHeap) Pop() interface{} {
+	last := h.vertices[len(h.vertices)-1]
+	h.vertices = h.vertices[:len(h.vertices)-1]
+	return last
+}
+
+// Returns the number of edges into each vertex. A self-loop counts as an edge
+// into its vertex.
+func (g *Graph) inDegrees() []int {
+	inDegree := make([]int, adjustSize(g.nVertices))
+	for x := 1; x <= g.nVertices; x++ {
+		for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+			inDegree[edgePointer.y]++
+		}
+	}
+	return inDegree
+}
+
+// Returns the error for a sort that could not place the vertices whose
+// in-degree has not reached 0.
+func (g *Graph) cyclicSubgraph(inDegree []int) error {
+	var vertices []int
+	for v := 1; v <= g.nVertices; v++ {
+		if inDegree[v] > 0 {
+			vertices = append(vertices, v)
+		}
+	}
+	sub, _ := g.InducedSubgraph(vertices)
+	return &CyclicSubgraphError{Vertices: vertices, Subgraph: sub}
+}
+
+// KahnTopologicalSort returns vertices in topological order, so every edge
+// runs from an earlier vertex to a later one, using Kahn's algorithm. Whenever
+// several vertices are ready, the one that sorts first by less is taken. A nil
+// less takes the lowest vertex first, giving the lexicographically smallest
+// order. Either way the result does not depend on the order edges were added.
+//
+// ErrNotDirected is returned for undirected graphs. If there is a cycle, a
+// *CyclicSubgraphError holding the vertices that could not be sorted is
+// returned.
+func (g *Graph) KahnTopologicalSort(less func(a int, b int) bool) ([]int, error) {
+	if g.directed == false {
+		return nil, ErrNotDirected
+	}
+	if less == nil {
+		less = func(a int, b int) bool { return a < b }
+	}
+
+	inDegree := g.inDegrees()
+	ready := &vertexHeap{less: less}
+	for v := 1; v <= g.nVertices; v++ {
+		if inDegree[v] == 0 {
+			ready.vertices = append(ready.vertices, v)
+		}
+	}
+	heap.Init(ready)
+
+	sorted := make([]int, 0, g.nVertices)
+	for ready.Len() != 0 {
+		x := heap.Pop(ready).(int)
+		sorted = append(sorted, x)
+		for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+			inDegree[edgePointer.y]--
+			if inDegree[edgePointer.y] == 0 {
+				heap.Push(ready, edgePointer.y)
+			}
+		}
+	}
+
+	if len(sorted) != g.nVertices {
+		return nil, g.cyclicSubgraph(inDegree)
+	}
+	return sorted, nil
+}
+
+// TopologicalLevels splits a directed acyclic graph into levels. The first
+// level holds the vertices with no incoming edges, and each later level holds
+// the vertices whose predecessors are all in earlier levels, so the vertices
+// of a level have no edges between them and can be handled in parallel. Each
+// level is in increasing order. Errors are the same as KahnTopologicalSort.
+func (g *Graph) TopologicalLevels() ([][]int, error) {
+	if g.directed == false {
+		return nil, ErrNotDirected
+	}
+
+	inDegree := g.inDegrees()
+	var level []int
+	for v := 1; v <= g.nVertices; v++ {
+		if inDegree[v] == 0 {
+			level = append(level, v)
+		}
+	}
+
+	var levels [][]int
+	placed := 0
+	for len(level) != 0 {
+		levels = append(levels, level)
+		placed += len(level)
+
+		var next []int
+		for _, x := range level {
+			for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+				inDegree[edgePointer.y]--
+				if inDegree[edgePointer.y] == 0 {
+					next = append(next, edgePointer.y)
+				}
+			}
+		}
+		sort.Ints(next)
+		level = next
+	}
+
+	if placed != g.nVertices {
+		return nil, g.cyclicSubgraph(inDegree)
+	}
+	return levels, nil
+}
+
+// The most vertices CountTopologicalSorts accepts. 20! linear extensions still
+// fit in a uint64.
+const maxCountVertices = 20
+
+// Returns ErrNotDirected for undirected graphs, and a *CycleError if the graph
+// has a cycle. A cycle shows up as a strongly connected component of more than
+// one vertex, or as a self-loop.
+func (g *Graph) checkAcyclic() error {
+	count, _, err := g.StronglyConnectedComponents()
+	if err != nil {
+		return err
+	}
+	acyclic := count == g.nVertices
+	for v := 1; v <= g.nVertices && acyclic; v++ {
+		acyclic = !g.HasEdge(v, v)
+	}
+	if acyclic {
+		return nil
+	}
+
+	cycle := g.FindCycle()
+	return &CycleError{Edge: Edge{X: cycle[len(cycle)-1], Y: cycle[0]}, Cycle: cycle}
+}
+
+// AllTopologicalSorts calls fn with every topological order of a directed
+// acyclic graph, in lexicographic order. The slice is reused between calls, so
+// fn must copy it to keep it. Returning STOP from fn ends the enumeration, as
+// does reaching limit orders when limit is above 0. A graph can have up to n!
+// orders, so a limit is advisable for anything but small or nearly linear
+// graphs.
+//
+// ErrNotDirected is returned for undirected graphs, and a *CycleError for
+// graphs with a cycle.
+func (g *Graph) AllTopologicalSorts(limit int, fn func(order []int) Control) error {
+	if err := g.checkAcyclic(); err != nil {
+		return err
+	}
+
+	inDegree := g.inDegrees()
+	used := make([]bool, adjustSize(g.nVertices))
+	order := make([]int, 0, g.nVertices)
+	reported := 0
+
+	place := func(v int) {
+		used[v] = true
+		order = append(order, v)
+		for edgePointer := g.edges[v]; edgePointer != nil; edgePointer = edgePointer.next {
+			inDegree[edgePointer.y]--
+		}
+	}
+	unplace := func() {
+		v := order[len(order)-1]
+		used[v] = false
+		order = order[:len(order)-1]
+		for edgePointer := g.edges[v]; edgePointer != nil; edgePointer = edgePointer.next {
+			inDegree[edgePointer.y]++
+		}
+	}
+
+	// The explicit stack of the backtracking search. next[d] is the lowest
+	// vertex still to be tried at position d of the order.
+	next := []int{1}
+	for len(next) != 0 {
+		d := len(next) - 1
+
+		if d == g.nVertices {
+			reported++
+			if fn(order) == STOP || reported == limit {
+				return nil
+			}
+		} else {
+			v := next[d]
+			for v <= g.nVertices && (used[v] || inDegree[v] != 0) {
+				v++
+			}
+			if v <= g.nVertices {
+				next[d] = v + 1
+				place(v)
+				next = append(next, 1)
+				continue
+			}
+		}
+
+		// Every vertex has been tried at position d.
+		next = next[:d]
+		if d != 0 {
+			unplace()
+		}
+	}
+
+	return nil
+}
+
+// CountTopologicalSorts returns the number of topological orders of a directed
+// acyclic graph, also known as its linear extensions. It takes time and memory
+// exponential in the number of vertices, so graphs of more than 20 vertices
+// return ErrTooManyVertices. Otherwise errors are the same as
+// AllTopologicalSorts.
+func (g *Graph) CountTopologicalSorts() (uint64, error) {
+	if g.nVertices > maxCountVertices {
+		return 0, ErrTooManyVertices
+	}
+	if err := g.checkAcyclic(); err != nil {
+		return 0, err
+	}
+
+	// Bit v-1 stands for vertex v. The predecessors of each vertex as a set.
+	predecessors := make([]uint32, adjustSize(g.nVertices))
+	for x := 1; x <= g.nVertices; x++ {
+		for edgePointer := g.edges[x]; edgePointer != nil; edgePointer = edgePointer.next {
+			predecessors[edgePointer.y] |= 1 << (x - 1)
+		}
+	}
+
+	// ways[set] is the number of orders in which the vertices of set can be
+	// placed first.
+	full := uint32(1)<<g.nVertices - 1
+	ways := make([]uint64, full+1)
+	ways[0] = 1
+	for set := uint32(0); set < full; set++ {
+		if ways[set] == 0 {
+			continue
+		}
+		for v := 1; v <= g.nVertices; v++ {
+			bit := uint32(1) << (v - 1)
+			if set&bit == 0 && predecessors[v]&^set == 0 {
+				ways[set|bit] += ways[set]
+			}
+		}
+	}
+
+	return ways[full], nil
+}
@@ -0,0 +1,295 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestKahnTopologicalSort(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		2, 3,
+		2, 4,
+		4, 5,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	sorted, err := graph.KahnTopologicalSort(nil)
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	expected := []int{1, 2, 3, 4, 5}
+	for i := range expected {
+		if sorted[i] != expected[i] {
+			t.Errorf("sorted should be %v, got %v", expected, sorted)
+			break
+		}
+	}
+}
+
+func TestKahnTopologicalSort_edgeOrder(t *testing.T) {
+	// The same graph with its edges added in opposite orders.
+	forward := &Graph{}
+	forward.Init(true, []int{1, 3, 2, 3, 3, 4, 2, 4})
+	backward := &Graph{}
+	backward.Init(true, []int{2, 4, 3, 4, 2, 3, 1, 3})
+
+	a, _ := forward.KahnTopologicalSort(nil)
+	b, _ := backward.KahnTopologicalSort(nil)
+
+	expected := []int{1, 2, 3, 4}
+	for i := range expected {
+		if a[i] != expected[i] || b[i] != expected[i] {
+			t.Errorf("both should be %v, got %v and %v", expected, a, b)
+			break
+		}
+	}
+}
+
+func TestKahnTopologicalSort_less(t *testing.T) {
+	edgeList := []int{
+		1, 4,
+		2, 4,
+		3, 4,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	sorted, err := graph.KahnTopologicalSort(func(a int, b int) bool { return a > b })
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	expected := []int{3, 2, 1, 4}
+	for i := range expected {
+		if sorted[i] != expected[i] {
+			t.Errorf("sorted should be %v, got %v", expected, sorted)
+			break
+		}
+	}
+}
+
+func TestKahnTopologicalSort_cycle(t *testing.T) {
+	// 2, 3 and 4 form a cycle, 5 hangs off it, and 1 leads into it.
+	edgeList := []int{
+		1, 2,
+		2, 3,
+		3, 4,
+		4, 2,
+		4, 5,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	_, err := graph.KahnTopologicalSort(nil)
+	if !errors.Is(err, ErrCycle) {
+		t.Fatalf("err should match ErrCycle, got %v", err)
+	}
+
+	var cyclicErr *CyclicSubgraphError
+	if !errors.As(err, &cyclicErr) {
+		t.Fatalf("err should be a *CyclicSubgraphError, got %T", err)
+	}
+	expected := []int{2, 3, 4, 5}
+	if len(cyclicErr.Vertices) != len(expected) {
+		t.Fatalf("Vertices should be %v, got %v", expected, cyclicErr.Vertices)
+	}
+	for i := range expected {
+		if cyclicErr.Vertices[i] != expected[i] {
+			t.Errorf("Vertices should be %v, got %v", expected, cyclicErr.Vertices)
+			break
+		}
+	}
+	if cyclicErr.Subgraph.nEdges != 4 {
+		t.Errorf("Subgraph.nEdges should be 4, got %v", cyclicErr.Subgraph.nEdges)
+	}
+}
+
+func TestKahnTopologicalSort_undirected(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(false, []int{1, 2})
+
+	if _, err := graph.KahnTopologicalSort(nil); err != ErrNotDirected {
+		t.Errorf("err should be ErrNotDirected, got %v", err)
+	}
+}
+
+func TestTopologicalLevels(t *testing.T) {
+	edgeList := []int{
+		1, 3,
+		2, 3,
+		3, 4,
+		1, 4,
+		2, 5,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	levels, err := graph.TopologicalLevels()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	expected := [][]int{{1, 2}, {3, 5}, {4}}
+	if len(levels) != len(expected) {
+		t.Fatalf("levels should be %v, got %v", expected, levels)
+	}
+	for i := range expected {
+		if len(levels[i]) != len(expected[i]) {
+			t.Errorf("levels should be %v, got %v", expected, levels)
+			break
+		}
+		for j := range expected[i] {
+			if levels[i][j] != expected[i][j] {
+				t.Errorf("levels should be %v, got %v", expected, levels)
+				break
+			}
+		}
+	}
+}
+
+func TestTopologicalLevels_selfLoop(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(true, []int{1, 2, 2, 2})
+
+	_, err := graph.TopologicalLevels()
+	var cyclicErr *CyclicSubgraphError
+	if !errors.As(err, &cyclicErr) {
+		t.Fatalf("err should be a *CyclicSubgraphError, got %v", err)
+	}
+	if len(cyclicErr.Vertices) != 1 || cyclicErr.Vertices[0] != 2 {
+		t.Errorf("Vertices should be [2], got %v", cyclicErr.Vertices)
+	}
+}
+
+// Two paths from 1 to 4.
+func diamondGraph() *Graph {
+	edgeList := []int{
+		1, 2,
+		1, 3,
+		2, 4,
+		3, 4,
+	}
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+	return graph
+}
+
+func TestAllTopologicalSorts(t *testing.T) {
+	graph := diamondGraph()
+
+	var orders [][]int
+	err := graph.AllTopologicalSorts(0, func(order []int) Control {
+		orders = append(orders, append([]int(nil), order...))
+		return CONTINUE
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	expected := [][]int{{1, 2, 3, 4}, {1, 3, 2, 4}}
+	if len(orders) != len(expected) {
+		t.Fatalf("orders should be %v, got %v", expected, orders)
+	}
+	for i := range expected {
+		for j := range expected[i] {
+			if orders[i][j] != expected[i][j] {
+				t.Errorf("orders should be %v, got %v", expected, orders)
+				break
+			}
+		}
+	}
+}
+
+func TestAllTopologicalSorts_limit(t *testing.T) {
+	// Four isolated vertices have 24 orders.
+	graph := &Graph{}
+	graph.Init(true, nil)
+	for i := 0; i < 4; i++ {
+		graph.AddVertex()
+	}
+
+	count := 0
+	graph.AllTopologicalSorts(5, func(order []int) Control {
+		count++
+		return CONTINUE
+	})
+	if count != 5 {
+		t.Errorf("count should be 5, got %v", count)
+	}
+
+	count = 0
+	graph.AllTopologicalSorts(0, func(order []int) Control {
+		count++
+		if count == 3 {
+			return STOP
+		}
+		return CONTINUE
+	})
+	if count != 3 {
+		t.Errorf("count should be 3, got %v", count)
+	}
+}
+
+func TestAllTopologicalSorts_cycle(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(true, []int{1, 2, 2, 3, 3, 2})
+
+	err := graph.AllTopologicalSorts(0, func(order []int) Control {
+		t.Errorf("fn should not be called, got %v", order)
+		return CONTINUE
+	})
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("err should be a *CycleError, got %v", err)
+	}
+	if len(cycleErr.Cycle) != 2 {
+		t.Errorf("cycleErr.Cycle should hold 2 and 3, got %v", cycleErr.Cycle)
+	}
+}
+
+func TestCountTopologicalSorts(t *testing.T) {
+	graph := diamondGraph()
+
+	count, err := graph.CountTopologicalSorts()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+	if count != 2 {
+		t.Errorf("count should be 2, got %v", count)
+	}
+
+	// Twenty isolated vertices have 20! orders.
+	graph = &Graph{}
+	graph.Init(true, nil)
+	for i := 0; i < 20; i++ {
+		graph.AddVertex()
+	}
+	count, err = graph.CountTopologicalSorts()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+	if count != 2432902008176640000 {
+		t.Errorf("count should be 2432902008176640000, got %v", count)
+	}
+
+	graph.AddVertex()
+	if _, err := graph.CountTopologicalSorts(); err != ErrTooManyVertices {
+		t.Errorf("err should be ErrTooManyVertices, got %v", err)
+	}
+}
+
+func TestCountTopologicalSorts_selfLoop(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(true, []int{1, 2, 2, 2})
+
+	if _, err := graph.CountTopologicalSorts(); !errors.Is(err, ErrCycle) {
+		t.Errorf("err should match ErrCycle, got %v", err)
+	}
+}
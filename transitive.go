@@ -0,0 +1,139 @@
+package graph
+
+// A set of small non-negative integers.
+type bitset []uint64
+
+func newBitset(size int) bitset {
+	return make(bitset, (size+63)/64)
+}
+
+func (b bitset) add(i int) {
+	b[i/64] |= 1 << (i % 64)
+}
+
+func (b bitset) has(i int) bool {
+	return b[i/64]&(1<<(i%64)) != 0
+}
+
+// Adds every member of other to b.
+func (b bitset) union(other bitset) {
+	for i := range b {
+		b[i] |= other[i]
+	}
+}
+
+// Reachability is an index of which vertices of a directed graph can reach
+// which others, as built by TransitiveClosure. It stores a set of reachable
+// strongly connected components for each component, since every vertex of a
+// component reaches the same vertices. It is not updated when the graph changes.
+type Reachability struct {
+	component []int    // The strongly connected component of each vertex.
+	reach     []bitset // The components each component reaches.
+}
+
+// Reachable reports whether there is a path of at least one edge from u to v.
+// A vertex reaches itself only if it is on a cycle. Vertices outside the graph
+// reach nothing.
+func (r *Reachability) Reachable(u int, v int) bool {
+	if u < 1 || u >= len(r.component) || v < 1 || v >= len(r.component) {
+		return false
+	}
+	return r.reach[r.component[u]].has(r.component[v])
+}
+
+// Returns the condensation of a directed graph, the component of each vertex,
+// and for each component the components reachable from it through at least
+// one condensation edge. A component is never its own descendant.
+func (g *Graph) descendants() (*Graph, [][]int, []int, []bitset, error) {
+	dag, members, err := g.Condensation()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	component := make([]int, adjustSize(g.nVertices))
+	for c := 1; c <= dag.nVertices; c++ {
+		for _, v := range members[c] {
+			component[v] = c
+		}
+	}
+
+	// Condensation edges run from higher to lower components, so the
+	// descendants of every successor are known before they are needed.
+	descendants := make([]bitset, adjustSize(dag.nVertices))
+	for c := 1; c <= dag.nVertices; c++ {
+		descendants[c] = newBitset(adjustSize(dag.nVertices))
+		for edgePointer := dag.edges[c]; edgePointer != nil; edgePointer = edgePointer.next {
+			descendants[c].add(edgePointer.y)
+			descendants[c].union(descendants[edgePointer.y])
+		}
+	}
+
+	return dag, members, component, descendants, nil
+}
+
+// TransitiveClosure returns an index answering whether one vertex of a
+// directed graph can reach another in constant time. It is built on the
+// condensation, so it takes memory quadratic in the number of strongly
+// connected components rather than vertices. ErrNotDirected is returned for
+// undirected graphs.
+func (g *Graph) TransitiveClosure() (*Reachability, error) {
+	dag, members, component, reach, err := g.descendants()
+	if err != nil {
+		return nil, err
+	}
+
+	// Members of a component on a cycle reach each other and themselves.
+	for c := 1; c <= dag.nVertices; c++ {
+		if len(members[c]) > 1 || g.HasEdge(members[c][0], members[c][0]) {
+			reach[c].add(c)
+		}
+	}
+
+	return &Reachability{component: component, reach: reach}, nil
+}
+
+// TransitiveReduction returns a new directed graph on the same vertices with
+// the same reachability and as few edges as possible. Each strongly connected
+// component of more than one vertex becomes a cycle through its members in
+// increasing order, and a self-loop is kept only on a vertex that is its own
+// component. Between components, an edge runs from the lowest member of one
+// to the lowest member of the other, and only where there is no longer route.
+// For an acyclic graph this is the unique transitive reduction, a subgraph of
+// the original. Every edge has a weight of 1. ErrNotDirected is returned for
+// undirected graphs.
+func (g *Graph) TransitiveReduction() (*Graph, error) {
+	dag, members, _, descendants, err := g.descendants()
+	if err != nil {
+		return nil, err
+	}
+
+	reduced := &Graph{
+		directed:  true,
+		edges:     make([]*edge, adjustSize(g.nVertices)),
+		nVertices: g.nVertices,
+	}
+
+	for c := 1; c <= dag.nVertices; c++ {
+		cycle := members[c]
+		if len(cycle) > 1 {
+			for i, v := range cycle {
+				reduced.insertEdge(true, v, cycle[(i+1)%len(cycle)], 1)
+			}
+		} else if g.HasEdge(cycle[0], cycle[0]) {
+			reduced.insertEdge(true, cycle[0], cycle[0], 1)
+		}
+
+		// A successor is redundant if another successor already reaches it.
+		indirect := newBitset(adjustSize(dag.nVertices))
+		for edgePointer := dag.edges[c]; edgePointer != nil; edgePointer = edgePointer.next {
+			indirect.union(descendants[edgePointer.y])
+		}
+		for edgePointer := dag.edges[c]; edgePointer != nil; edgePointer = edgePointer.next {
+			if !indirect.has(edgePointer.y) {
+				reduced.insertEdge(true, members[c][0], members[edgePointer.y][0], 1)
+			}
+		}
+	}
+
+	return reduced, nil
+}
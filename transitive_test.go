@@ -0,0 +1,107 @@
+package graph
+
+import "testing"
+
+func TestTransitiveClosure(t *testing.T) {
+	// 2 and 3 form a cycle between 1 and 4. 5 only has a self-loop.
+	edgeList := []int{
+		1, 2,
+		2, 3,
+		3, 2,
+		3, 4,
+		5, 5,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	closure, err := graph.TransitiveClosure()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	tests := []struct {
+		u, v      int
+		reachable bool
+	}{
+		{1, 4, true},
+		{3, 2, true},
+		{2, 2, true},
+		{5, 5, true},
+		{1, 1, false},
+		{4, 1, false},
+		{1, 5, false},
+		{1, 6, false},
+	}
+	for _, test := range tests {
+		if closure.Reachable(test.u, test.v) != test.reachable {
+			t.Errorf("Reachable(%v, %v) should be %v", test.u, test.v, test.reachable)
+		}
+	}
+}
+
+func TestTransitiveClosure_undirected(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(false, []int{1, 2})
+
+	if _, err := graph.TransitiveClosure(); err != ErrNotDirected {
+		t.Errorf("err should be ErrNotDirected, got %v", err)
+	}
+}
+
+func TestTransitiveReduction(t *testing.T) {
+	// A chain with every shortcut, plus a duplicate edge.
+	edgeList := []int{
+		1, 2,
+		2, 3,
+		3, 4,
+		1, 3,
+		1, 4,
+		2, 4,
+		2, 3,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	reduced, err := graph.TransitiveReduction()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if reduced.nEdges != 3 {
+		t.Errorf("reduced.nEdges should be 3, got %v", reduced.nEdges)
+	}
+	for v := 1; v < 4; v++ {
+		if !reduced.HasEdge(v, v+1) {
+			t.Errorf("reduced should have an edge from %v to %v", v, v+1)
+		}
+	}
+}
+
+func TestTransitiveReduction_cycle(t *testing.T) {
+	// Every ordered pair of 1, 2 and 3, with 4 reached from each.
+	graph := completeDigraph()
+	graph.AddVertex()
+	graph.AddEdge(1, 4)
+	graph.AddEdge(2, 4)
+	graph.AddEdge(3, 4)
+
+	reduced, err := graph.TransitiveReduction()
+	if err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if reduced.nEdges != 4 {
+		t.Errorf("reduced.nEdges should be 4, got %v", reduced.nEdges)
+	}
+	original, _ := graph.TransitiveClosure()
+	closure, _ := reduced.TransitiveClosure()
+	for u := 1; u <= 4; u++ {
+		for v := 1; v <= 4; v++ {
+			if closure.Reachable(u, v) != original.Reachable(u, v) {
+				t.Errorf("Reachable(%v, %v) should be %v", u, v, original.Reachable(u, v))
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package graph
+
+// UnionFind is a disjoint-set forest over the elements 1 to n, using path
+// compression and union by rank. Feeding it a stream of edges with Union and
+// reading Count gives the number of connected components without building a
+// Graph.
+type UnionFind struct {
+	parent []int
+	rank   []int
+	count  int
+}
+
+// NewUnionFind returns a UnionFind with each of the elements 1 to n in its own
+// set.
+func NewUnionFind(n int) *UnionFind {
+	u := &UnionFind{
+		parent: make([]int, adjustSize(n)),
+		rank:   make([]int, adjustSize(n)),
+		count:  n,
+	}
+	for i := range u.parent {
+		u.parent[i] = i
+	}
+	return u
+}
+
+// Find returns the representative element of the set containing x.
+func (u *UnionFind) Find(x int) int {
+	root := x
+	for u.parent[root] != root {
+		root = u.parent[root]
+	}
+	// Point everything on the path directly at the root.
+	for u.parent[x] != root {
+		x, u.parent[x] = u.parent[x], root
+	}
+	return root
+}
+
+// Union merges the sets containing x and y. It returns false if they were
+// already in the same set.
+func (u *UnionFind) Union(x int, y int) bool {
+	rootX, rootY := u.Find(x), u.Find(y)
+	if rootX == rootY {
+		return false
+	}
+	// Hang the shorter tree beneath the taller one.
+	if u.rank[rootX] < u.rank[rootY] {
+		rootX, rootY = rootY, rootX
+	}
+	u.parent[rootY] = rootX
+	if u.rank[rootX] == u.rank[rootY] {
+		u.rank[rootX]++
+	}
+	u.count--
+	return true
+}
+
+// Connected checks if x and y are in the same set.
+func (u *UnionFind) Connected(x int, y int) bool {
+	return u.Find(x) == u.Find(y)
+}
+
+// Count returns the number of disjoint sets.
+func (u *UnionFind) Count() int {
+	return u.count
+}
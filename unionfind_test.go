@@ -0,0 +1,28 @@
+package graph
+
+import "testing"
+
+func TestUnionFind(t *testing.T) {
+	sets := NewUnionFind(5)
+
+	if sets.Count() != 5 {
+		t.Errorf("sets.Count() should be 5, got %v", sets.Count())
+	}
+
+	sets.Union(1, 2)
+	sets.Union(3, 4)
+	sets.Union(2, 4)
+
+	if sets.Union(1, 3) != false {
+		t.Errorf("sets.Union(1, 3) should be false, got true")
+	}
+	if sets.Count() != 2 {
+		t.Errorf("sets.Count() should be 2, got %v", sets.Count())
+	}
+	if sets.Connected(1, 4) != true {
+		t.Errorf("sets.Connected(1, 4) should be true, got false")
+	}
+	if sets.Connected(1, 5) != false {
+		t.Errorf("sets.Connected(1, 5) should be false, got true")
+	}
+}
@@ -0,0 +1,88 @@
+package graph
+
+// Visitor receives events from BreadthFirstVisit and DepthFirstVisit, in the
+// style of Boost Graph Library event visitors. Embed DefaultVisitor to
+// implement only the events of interest. Every event returns a Control, which
+// directs the traversal as it does for traversal callbacks.
+type Visitor interface {
+	// DiscoverVertex is called when a vertex is first processed.
+	DiscoverVertex(v int, data *TraversalData) Control
+	// FinishVertex is called once all of a vertex's edges have been examined.
+	FinishVertex(v int, data *TraversalData) Control
+	// TreeEdge is called for an edge to an undiscovered vertex, before it is
+	// discovered through it.
+	TreeEdge(x int, y int, data *TraversalData) Control
+	// BackEdge is called for an edge to an ancestor during depth-first visits.
+	BackEdge(x int, y int, data *TraversalData) Control
+	// ForwardEdge is called for an edge to a finished descendant during
+	// depth-first visits of directed graphs.
+	ForwardEdge(x int, y int, data *TraversalData) Control
+	// CrossEdge is called for an edge to a finished vertex in another subtree
+	// during depth-first visits of directed graphs.
+	CrossEdge(x int, y int, data *TraversalData) Control
+	// NonTreeEdge is called for an edge to an already discovered vertex during
+	// breadth-first visits.
+	NonTreeEdge(x int, y int, data *TraversalData) Control
+}
+
+// DefaultVisitor implements every Visitor event by returning CONTINUE.
+type DefaultVisitor struct{}
+
+// DiscoverVertex returns CONTINUE.
+func (DefaultVisitor) DiscoverVertex(v int, data *TraversalData) Control { return CONTINUE }
+
+// FinishVertex returns CONTINUE.
+func (DefaultVisitor) FinishVertex(v int, data *TraversalData) Control { return CONTINUE }
+
+// TreeEdge returns CONTINUE.
+func (DefaultVisitor) TreeEdge(x int, y int, data *TraversalData) Control { return CONTINUE }
+
+// BackEdge returns CONTINUE.
+func (DefaultVisitor) BackEdge(x int, y int, data *TraversalData) Control { return CONTINUE }
+
+// ForwardEdge returns CONTINUE.
+func (DefaultVisitor) ForwardEdge(x int, y int, data *TraversalData) Control { return CONTINUE }
+
+// CrossEdge returns CONTINUE.
+func (DefaultVisitor) CrossEdge(x int, y int, data *TraversalData) Control { return CONTINUE }
+
+// NonTreeEdge returns CONTINUE.
+func (DefaultVisitor) NonTreeEdge(x int, y int, data *TraversalData) Control { return CONTINUE }
+
+// BreadthFirstVisit runs BreadthFirstTraversal from start, reporting events to
+// vis. Every examined edge is either a tree edge or a non-tree edge.
+func (g *Graph) BreadthFirstVisit(start int, vis Visitor, data *TraversalData) *TraversalData {
+	pe := func(x int, y int, data *TraversalData) Control {
+		if data.discovered[y] == false {
+			return vis.TreeEdge(x, y, data)
+		}
+		return vis.NonTreeEdge(x, y, data)
+	}
+	return g.BreadthFirstTraversal(start, vis.DiscoverVertex, vis.FinishVertex, pe, data)
+}
+
+// DepthFirstVisit runs DepthFirstTraversal from start, reporting events to vis.
+// Each examined edge is classified as a tree, back, forward or cross edge.
+// Undirected graphs only have tree and back edges.
+func (g *Graph) DepthFirstVisit(start int, vis Visitor, data *TraversalData) (*TraversalData, error) {
+	var err error
+	pe := func(x int, y int, data *TraversalData) Control {
+		class, classErr := edgeClassification(x, y, data)
+		if classErr != nil {
+			err = classErr
+			return STOP
+		}
+		switch class {
+		case TREE:
+			return vis.TreeEdge(x, y, data)
+		case BACK:
+			return vis.BackEdge(x, y, data)
+		case FORWARD:
+			return vis.ForwardEdge(x, y, data)
+		default:
+			return vis.CrossEdge(x, y, data)
+		}
+	}
+	g.DepthFirstTraversal(start, vis.DiscoverVertex, vis.FinishVertex, pe, data)
+	return data, err
+}
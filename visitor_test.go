@@ -0,0 +1,114 @@
+package graph
+
+import "testing"
+
+// Counts each kind of edge event.
+type edgeCounter struct {
+	DefaultVisitor
+	tree, back, forward, cross, nonTree int
+}
+
+func (c *edgeCounter) TreeEdge(x int, y int, data *TraversalData) Control {
+	c.tree++
+	return CONTINUE
+}
+
+func (c *edgeCounter) BackEdge(x int, y int, data *TraversalData) Control {
+	c.back++
+	return CONTINUE
+}
+
+func (c *edgeCounter) ForwardEdge(x int, y int, data *TraversalData) Control {
+	c.forward++
+	return CONTINUE
+}
+
+func (c *edgeCounter) CrossEdge(x int, y int, data *TraversalData) Control {
+	c.cross++
+	return CONTINUE
+}
+
+func (c *edgeCounter) NonTreeEdge(x int, y int, data *TraversalData) Control {
+	c.nonTree++
+	return CONTINUE
+}
+
+func TestDepthFirstVisit(t *testing.T) {
+	// 1's edges are examined in the order 4, 2, 3.
+	edgeList := []int{
+		1, 3, // Forward edge.
+		1, 2,
+		2, 3, // Cross edge.
+		3, 1, // Back edge.
+		1, 4,
+		4, 3,
+	}
+
+	graph := &Graph{}
+	graph.Init(true, edgeList)
+
+	counter := &edgeCounter{}
+	data := &TraversalData{}
+	data.Init(graph)
+	if _, err := graph.DepthFirstVisit(1, counter, data); err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if counter.tree != 3 {
+		t.Errorf("counter.tree should be 3, got %v", counter.tree)
+	}
+	if counter.back != 1 {
+		t.Errorf("counter.back should be 1, got %v", counter.back)
+	}
+	if counter.forward != 1 {
+		t.Errorf("counter.forward should be 1, got %v", counter.forward)
+	}
+	if counter.cross != 1 {
+		t.Errorf("counter.cross should be 1, got %v", counter.cross)
+	}
+}
+
+func TestDepthFirstVisit_parallelEdges(t *testing.T) {
+	graph := &Graph{}
+	graph.Init(true, []int{1, 2, 1, 2})
+
+	counter := &edgeCounter{}
+	data := &TraversalData{}
+	data.Init(graph)
+	if _, err := graph.DepthFirstVisit(1, counter, data); err != nil {
+		t.Fatalf("err should be nil, got %v", err)
+	}
+
+	if counter.tree != 1 {
+		t.Errorf("counter.tree should be 1, got %v", counter.tree)
+	}
+	if counter.forward != 1 {
+		t.Errorf("counter.forward should be 1, got %v", counter.forward)
+	}
+}
+
+func TestBreadthFirstVisit(t *testing.T) {
+	edgeList := []int{
+		1, 2,
+		1, 3,
+		2, 3,
+	}
+
+	graph := &Graph{}
+	graph.Init(false, edgeList)
+
+	counter := &edgeCounter{}
+	data := &TraversalData{}
+	data.Init(graph)
+	graph.BreadthFirstVisit(1, counter, data)
+
+	if counter.tree != 2 {
+		t.Errorf("counter.tree should be 2, got %v", counter.tree)
+	}
+	if counter.nonTree != 1 {
+		t.Errorf("counter.nonTree should be 1, got %v", counter.nonTree)
+	}
+	if counter.back != 0 {
+		t.Errorf("counter.back should be 0, got %v", counter.back)
+	}
+}